@@ -0,0 +1,275 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// 解析multipart表单时存储在内存中的最大字节数（超出部分存储在临时文件中）
+const defaultMultipartMemory = 32 << 20
+
+// 绑定请求数据时请求体的最大字节数，小于等于0时不限制
+var BindMaxBodySize int64 = 10 << 20
+
+// 请求体超出最大字节数（可通过errors.Is判断）
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// 不支持的请求数据格式（可通过errors.Is判断）
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// 请求数据绑定错误（请求数据格式错误等）
+type BindError struct {
+	Source string // 数据来源：json、query、form、multipart
+	Field  string // 字段（json标签），请求数据整体错误时为空
+	Err    error  // 原始错误
+}
+
+// 错误信息
+func (e *BindError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("请求数据%s字段%s解析失败：%s", e.Source, e.Field, e.Err.Error())
+	}
+	return fmt.Sprintf("请求数据%s解析失败：%s", e.Source, e.Err.Error())
+}
+
+// 获取原始错误
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// 不含原始错误的错误信息（用于返回给客户端）
+func (e *BindError) message() string {
+	switch {
+	case errors.Is(e.Err, ErrBodyTooLarge):
+		return "请求体超出大小限制"
+	case errors.Is(e.Err, ErrUnsupportedMediaType):
+		return "不支持的请求数据格式"
+	case e.Field != "":
+		return fmt.Sprintf("字段%s格式错误", e.Field)
+	}
+	return "请求数据格式错误"
+}
+
+// 绑定请求数据并验证
+// 依次读取URL查询参数、请求体（根据Content-Type解析json、x-www-form-urlencoded、multipart/form-data），请求体的数据优先
+// 请求数据根据json标签转换为验证器属性的类型后设置为验证数据，再使用请求的上下文验证指定场景
+// 请求体超出BindMaxBodySize、请求数据格式错误、Content-Type不支持时返回*BindError，验证失败时返回的错误与CheckScene一致
+func Bind(r *http.Request, v ValidatorInterface, scene string, opts ...CheckOption) (err error) {
+	v.InitValidator(v)
+	opts = applyCoerceOption(v, opts)
+	datas, err := requestDatas(r, v)
+	if err != nil {
+		return
+	}
+	err = v.SetDatas(datas)
+	if err != nil {
+		return
+	}
+	err = v.CheckSceneContext(r.Context(), scene, opts...)
+	return
+}
+
+// 获取请求数据
+func requestDatas(r *http.Request, v ValidatorInterface) (datas map[string]interface{}, err error) {
+	fieldTypes := validatorFieldTypes(v)
+	datas = map[string]interface{}{}
+	if r.URL != nil {
+		err = setFormDatas(datas, r.URL.Query(), fieldTypes, "query")
+		if err != nil {
+			return
+		}
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		err = &BindError{Source: "body", Err: err}
+		return
+	}
+	var body *limitedBody
+	if BindMaxBodySize > 0 {
+		body = &limitedBody{ReadCloser: r.Body, remaining: BindMaxBodySize}
+		r.Body = body
+	}
+	switch {
+	case mediaType == "application/json" || (len(mediaType) > 5 && mediaType[len(mediaType)-5:] == "+json"):
+		err = setJsonDatas(datas, r.Body, fieldTypes)
+	case mediaType == "application/x-www-form-urlencoded":
+		if err = r.ParseForm(); err != nil {
+			err = &BindError{Source: "form", Err: err}
+			break
+		}
+		err = setFormDatas(datas, r.PostForm, fieldTypes, "form")
+	case mediaType == "multipart/form-data":
+		if err = r.ParseMultipartForm(defaultMultipartMemory); err != nil {
+			err = &BindError{Source: "multipart", Err: err}
+			break
+		}
+		err = setFormDatas(datas, r.MultipartForm.Value, fieldTypes, "multipart")
+		if err != nil {
+			break
+		}
+		setFileDatas(datas, r.MultipartForm.File, fieldTypes)
+	default:
+		err = &BindError{Source: "body", Err: fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)}
+	}
+	// 读取请求体的错误可能被包装为其他错误（如multipart），根据是否超出限制判断
+	if err != nil && body != nil && body.exceeded {
+		err = &BindError{Source: "body", Err: fmt.Errorf("%w: limit %d bytes", ErrBodyTooLarge, BindMaxBodySize)}
+	}
+	return
+}
+
+// 限制读取字节数的请求体
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64 // 剩余可读取的字节数
+	exceeded  bool  // 是否超出限制
+}
+
+// 读取请求体，超出限制时返回ErrBodyTooLarge
+func (b *limitedBody) Read(p []byte) (n int, err error) {
+	if b.exceeded {
+		return 0, ErrBodyTooLarge
+	}
+	// 多读取1个字节以判断是否超出限制
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err = b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		b.exceeded = true
+		return n, ErrBodyTooLarge
+	}
+	b.remaining -= int64(n)
+	return
+}
+
+// 获取验证器属性类型（键为json标签）
+func validatorFieldTypes(v ValidatorInterface) map[string]reflect.Type {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	t = t.Elem()
+	plan := planOf(t)
+	fieldTypes := make(map[string]reflect.Type, len(plan.fields))
+	for _, field := range plan.fields {
+		fieldTypes[field.Key] = t.Field(field.Index).Type
+	}
+	return fieldTypes
+}
+
+// 设置json请求体数据，验证器属性的数据解析为属性类型，其他数据解析为interface{}
+func setJsonDatas(datas map[string]interface{}, body io.Reader, fieldTypes map[string]reflect.Type) (err error) {
+	raws := map[string]json.RawMessage{}
+	decoder := json.NewDecoder(body)
+	if err = decoder.Decode(&raws); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return &BindError{Source: "json", Err: err}
+	}
+	// 请求体只能包含一个json值
+	var extra json.RawMessage
+	if err = decoder.Decode(&extra); err != io.EOF {
+		if err == nil {
+			err = errors.New("请求体包含多余的数据")
+		}
+		return &BindError{Source: "json", Err: err}
+	}
+	for key, raw := range raws {
+		if fieldType, ok := fieldTypes[key]; ok && !bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			value := reflect.New(fieldType)
+			if err = json.Unmarshal(raw, value.Interface()); err != nil {
+				return &BindError{Source: "json", Field: key, Err: err}
+			}
+			datas[key] = value.Elem().Interface()
+			continue
+		}
+		var value interface{}
+		if err = json.Unmarshal(raw, &value); err != nil {
+			return &BindError{Source: "json", Field: key, Err: err}
+		}
+		datas[key] = value
+	}
+	return nil
+}
+
+// 设置表单数据，验证器属性的数据转换为属性类型，其他数据单个值为string，多个值为[]string
+func setFormDatas(datas map[string]interface{}, values url.Values, fieldTypes map[string]reflect.Type, source string) error {
+	for key, items := range values {
+		if len(items) == 0 {
+			continue
+		}
+		fieldType, ok := fieldTypes[key]
+		if !ok {
+			if len(items) == 1 {
+				datas[key] = items[0]
+			} else {
+				datas[key] = items
+			}
+			continue
+		}
+		value, err := formValue(items, fieldType)
+		if err != nil {
+			return &BindError{Source: source, Field: key, Err: err}
+		}
+		datas[key] = value
+	}
+	return nil
+}
+
+// 设置multipart表单的上传文件，属性类型为[]*multipart.FileHeader时设置所有文件，否则设置第一个文件
+func setFileDatas(datas map[string]interface{}, files map[string][]*multipart.FileHeader, fieldTypes map[string]reflect.Type) {
+	fileHeadersType := reflect.TypeOf([]*multipart.FileHeader(nil))
+	for key, items := range files {
+		if len(items) == 0 {
+			continue
+		}
+		if fieldType, ok := fieldTypes[key]; (ok && fieldType == fileHeadersType) || (!ok && len(items) > 1) {
+			datas[key] = items
+			continue
+		}
+		datas[key] = items[0]
+	}
+}
+
+// 将表单值转换为属性类型，切片类型转换所有值，其他类型转换第一个值
+func formValue(items []string, t reflect.Type) (interface{}, error) {
+	if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+		if len(items) == 1 {
+			return items[0], nil
+		}
+		return items, nil
+	}
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 && !reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := setStringValue(slice.Index(i), item); err != nil {
+				return nil, err
+			}
+		}
+		return slice.Interface(), nil
+	}
+	value := reflect.New(t).Elem()
+	if err := setStringValue(value, items[0]); err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type bindTestArticle struct {
+	Validator
+	Title  string `json:"title" validate:"required" title:"标题"`
+	CateId int    `json:"cate_id" title:"分类"`
+}
+
+func TestBindBodyErrors(t *testing.T) {
+	multipartBody := &bytes.Buffer{}
+	mw := multipart.NewWriter(multipartBody)
+	mw.WriteField("title", strings.Repeat("a", 64))
+	mw.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		maxBodySize int64
+		target      error
+		status      int
+	}{
+		{"json", "application/json", `{"title":"a","cate_id":1}`, 0, nil, 0},
+		{"json多余的数据", "application/json", `{"title":"a"} {}`, 0, nil, http.StatusBadRequest},
+		{"json多余的字符", "application/json", `{"title":"a"} garbage`, 0, nil, http.StatusBadRequest},
+		{"json超出大小限制", "application/json", `{"title":"` + strings.Repeat("a", 64) + `"}`, 32, ErrBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{"json未超出大小限制", "application/json", `{"title":"a"}`, 13, nil, 0},
+		{"表单超出大小限制", "application/x-www-form-urlencoded", "title=" + strings.Repeat("a", 64), 32, ErrBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{"multipart超出大小限制", mw.FormDataContentType(), multipartBody.String(), 32, ErrBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{"不支持的Content-Type", "text/plain", "title=a", 0, ErrUnsupportedMediaType, http.StatusUnsupportedMediaType},
+	}
+	defer func(size int64) { BindMaxBodySize = size }(BindMaxBodySize)
+	for _, test := range tests {
+		BindMaxBodySize = test.maxBodySize
+		r := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(test.body))
+		r.Header.Set("Content-Type", test.contentType)
+		err := Bind(r, &bindTestArticle{}, "")
+		if test.status == 0 {
+			if err != nil {
+				t.Errorf("%s：返回错误：%v", test.name, err)
+			}
+			continue
+		}
+		var bindErr *BindError
+		if !errors.As(err, &bindErr) {
+			t.Errorf("%s：返回错误 %v，期望 *BindError", test.name, err)
+			continue
+		}
+		if test.target != nil && !errors.Is(err, test.target) {
+			t.Errorf("%s：返回错误 %v，期望 %v", test.name, err, test.target)
+		}
+		if status := DefaultProblemRenderer.Problem(r, err).Status; status != test.status {
+			t.Errorf("%s：状态码 = %d，期望 %d", test.name, status, test.status)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// 验证器接口类型
+var validatorInterfaceType = reflect.TypeOf((*ValidatorInterface)(nil)).Elem()
+
+// 子验证器属性（属性类型为验证器结构体、验证器结构体指针或其切片、数组）
+type childField struct {
+	Index int    // 属性下标
+	Scene string // 子验证器验证场景（属性scene标签定义）
+}
+
+// 判断类型是否为验证器结构体或验证器结构体指针
+func isValidatorType(t reflect.Type) bool {
+	if t.Kind() == reflect.Struct {
+		return reflect.PtrTo(t).Implements(validatorInterfaceType)
+	}
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && t.Implements(validatorInterfaceType)
+}
+
+// 判断属性类型是否为子验证器
+func isChildValidatorType(t reflect.Type) bool {
+	if isValidatorType(t) {
+		return true
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return isValidatorType(t.Elem())
+	}
+	return false
+}
+
+// 获取属性中的子验证器（键为子验证器路径后缀，切片元素为下标）
+func childValidators(field reflect.Value) (keys []string, children []ValidatorInterface) {
+	// 获取可调用的验证器实例
+	instance := func(item reflect.Value) ValidatorInterface {
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				return nil
+			}
+		} else if item.CanAddr() {
+			item = item.Addr()
+		} else {
+			return nil
+		}
+		child, _ := item.Interface().(ValidatorInterface)
+		return child
+	}
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < field.Len(); i++ {
+			if child := instance(field.Index(i)); child != nil {
+				keys = append(keys, strconv.Itoa(i))
+				children = append(children, child)
+			}
+		}
+	default:
+		if child := instance(field); child != nil {
+			keys = append(keys, "")
+			children = append(children, child)
+		}
+	}
+	return
+}
+
+// 验证子验证器属性
+// 子验证器的验证场景依次为：属性scene标签定义的场景、子验证器定义的与当前验证场景同名的场景、全部验证
+// 验证失败的字段添加属性路径前缀，如：items.0.sku
+func (v *Validator) checkChild(dataKey string, datas map[string]interface{}) (err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	child, ok := v.childFields[dataKey]
+	if !ok {
+		return
+	}
+	field := v.validatorInstanceElem.Field(child.Index)
+	keys, children := childValidators(field)
+	errs := &Errors{}
+	for i, childValidator := range children {
+		path := dataKey
+		if keys[i] != "" {
+			path += "." + keys[i]
+		}
+		childValidator.InitValidator(childValidator)
+		v.setChildSupplied(childValidator, dataKey)
+		scene := child.Scene
+		if scene == "" && v.Scene != "" {
+			if scenes, _ := childValidator.GetScenes(); scenes != nil {
+				if _, ok := scenes[v.Scene]; ok {
+					scene = v.Scene
+				}
+			}
+		}
+		childErr := childValidator.CheckSceneContext(v.ctx, scene, WithBatch(v.batch), WithLocale(v.locale), withRegistries(v.callRegistries))
+		if childErr == nil {
+			continue
+		}
+		if errors.Is(childErr, ErrSystem) || isContextError(childErr) {
+			err = childErr
+			return
+		}
+		childErr = prefixError(path, childErr)
+		if !v.batch {
+			err = childErr
+			return
+		}
+		errs.Merge(childErr)
+	}
+	// 同步子验证器验证后的数据
+	datas[dataKey] = field.Interface()
+	if errs.Len() > 0 {
+		err = errs
+	}
+	return
+}
+
+// 设置子验证器已传入的数据（已传入的零值数据不设置默认值）
+// 子验证器初始化时零值的属性均视为未传入，上级属性已传入时（如绑定的请求数据）子验证器的属性均视为已传入
+func (v *Validator) setChildSupplied(childValidator ValidatorInterface, dataKey string) {
+	base, ok := childValidator.(validatorBase)
+	if !ok {
+		return
+	}
+	if value, exists := v.Datas[dataKey]; exists && !isNilValue(value) && !v.zeroKeys[dataKey] {
+		base.validatorBase().zeroKeys = nil
+	}
+}
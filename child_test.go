@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type childTestItem struct {
+	Validator
+	Sku  string `json:"sku" title:"商品编号"`
+	Qty  int    `json:"qty" title:"数量"`
+	Gift bool   `json:"gift" title:"赠品"`
+}
+
+func (i *childTestItem) DefineDefaults() map[string]interface{} {
+	return map[string]interface{}{"qty": 1, "gift": true}
+}
+
+type childTestOrder struct {
+	Validator
+	Items   []childTestItem `json:"items" title:"商品"`
+	Package childTestItem   `json:"package" title:"包装"`
+}
+
+func TestChildDefaultSuppliedZeroValue(t *testing.T) {
+	// 请求数据中传入的零值不设置默认值
+	r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"items":[{"sku":"a","qty":0,"gift":false}],"package":{"sku":"b"}}`))
+	r.Header.Set("Content-Type", "application/json")
+	order := &childTestOrder{}
+	if err := Bind(r, order, ""); err != nil {
+		t.Fatalf("Bind返回错误：%v", err)
+	}
+	if item := order.Items[0]; item.Qty != 0 || item.Gift {
+		t.Errorf("Items[0] = %d %v，期望保持传入的零值", item.Qty, item.Gift)
+	}
+
+	// 传入的子验证器属性
+	schema := MustNewSchema(&childTestOrder{})
+	result := schema.Validate("", map[string]interface{}{"items": []childTestItem{{Sku: "a"}}, "package": childTestItem{Sku: "b"}})
+	if !result.Valid {
+		t.Fatalf("Validate返回错误：%v", result.Err)
+	}
+	if item := result.Validator.(*childTestOrder).Items[0]; item.Qty != 0 || item.Gift {
+		t.Errorf("Items[0] = %d %v，期望保持传入的零值", item.Qty, item.Gift)
+	}
+
+	// 未传入的子验证器属性设置默认值
+	order = &childTestOrder{}
+	order.InitValidator(order)
+	if err := order.Check(); err != nil {
+		t.Fatalf("Check返回错误：%v", err)
+	}
+	if order.Package.Qty != 1 || !order.Package.Gift {
+		t.Errorf("Package = %d %v，期望 1 true", order.Package.Qty, order.Package.Gift)
+	}
+}
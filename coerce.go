@@ -0,0 +1,257 @@
+package validate
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// 文本反序列化接口类型
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// 时间类型
+var timeType = reflect.TypeOf(time.Time{})
+
+// 字符串转换为时间支持的格式（非RFC3339格式按本地时区解析）
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// 将值转换为指定类型
+// 支持：可直接赋值的类型、指针（自动取值、创建）、数字类型之间转换（检查溢出、小数）、数字字符串及json.Number、布尔字符串、
+// time.Time（字符串、秒级时间戳）、实现encoding.TextUnmarshaler的类型、切片（逐项转换）
+func coerceValue(value interface{}, t reflect.Type) (result reflect.Value, err error) {
+	result = reflect.New(t).Elem()
+	err = setCoercedValue(result, reflect.ValueOf(value))
+	return
+}
+
+// 将反射值转换后设置到目标反射值，nil设置为零值
+func setCoercedValue(dst reflect.Value, val reflect.Value) error {
+	// 源值为指针、接口时取其指向的值
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && !val.Type().AssignableTo(dst.Type()) {
+		if val.IsNil() {
+			val = reflect.Value{}
+			break
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if val.Type().AssignableTo(dst.Type()) {
+		dst.Set(val)
+		return nil
+	}
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := setCoercedValue(elem.Elem(), val); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case dst.Type() == timeType:
+		return setTimeValue(dst, val)
+	case val.Kind() == reflect.String:
+		return setStringValue(dst, val.String())
+	case dst.Kind() == reflect.Slice && (val.Kind() == reflect.Slice || val.Kind() == reflect.Array):
+		slice := reflect.MakeSlice(dst.Type(), val.Len(), val.Len())
+		for i := 0; i < val.Len(); i++ {
+			if err := setCoercedValue(slice.Index(i), val.Index(i)); err != nil {
+				return fmt.Errorf("第%d项：%w", i, err)
+			}
+		}
+		dst.Set(slice)
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = val.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if val.Uint() > math.MaxInt64 {
+				return fmt.Errorf("%v超出%v的范围", val.Uint(), dst.Type())
+			}
+			i = int64(val.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := val.Float()
+			if f != math.Trunc(f) {
+				return fmt.Errorf("%v不是整数", f)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("%v超出%v的范围", f, dst.Type())
+			}
+			i = int64(f)
+		default:
+			return unsupportedCoerceError(val, dst)
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("%v超出%v的范围", i, dst.Type())
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if val.Int() < 0 {
+				return fmt.Errorf("%v超出%v的范围", val.Int(), dst.Type())
+			}
+			u = uint64(val.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u = val.Uint()
+		case reflect.Float32, reflect.Float64:
+			f := val.Float()
+			if f != math.Trunc(f) {
+				return fmt.Errorf("%v不是整数", f)
+			}
+			if f < 0 || f >= math.MaxUint64 {
+				return fmt.Errorf("%v超出%v的范围", f, dst.Type())
+			}
+			u = uint64(f)
+		default:
+			return unsupportedCoerceError(val, dst)
+		}
+		if dst.OverflowUint(u) {
+			return fmt.Errorf("%v超出%v的范围", u, dst.Type())
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(val.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			f = float64(val.Uint())
+		case reflect.Float32, reflect.Float64:
+			f = val.Float()
+		default:
+			return unsupportedCoerceError(val, dst)
+		}
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("%v超出%v的范围", f, dst.Type())
+		}
+		dst.SetFloat(f)
+	default:
+		// 相同种类的类型（如自定义的type Status bool）
+		if val.Kind() == dst.Kind() && val.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(val.Convert(dst.Type()))
+			return nil
+		}
+		return unsupportedCoerceError(val, dst)
+	}
+	return nil
+}
+
+// 不支持转换的错误
+func unsupportedCoerceError(val reflect.Value, dst reflect.Value) error {
+	return fmt.Errorf("不支持将%v转换为%v", val.Type(), dst.Type())
+}
+
+// 将值转换为时间后设置到反射值，支持字符串（RFC3339、2006-01-02 15:04:05、2006-01-02等格式）、秒级时间戳
+func setTimeValue(dst reflect.Value, val reflect.Value) error {
+	var t time.Time
+	switch val.Kind() {
+	case reflect.String:
+		return setStringValue(dst, val.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		t = time.Unix(val.Int(), 0)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > math.MaxInt64 {
+			return fmt.Errorf("%v超出%v的范围", val.Uint(), dst.Type())
+		}
+		t = time.Unix(int64(val.Uint()), 0)
+	case reflect.Float32, reflect.Float64:
+		sec, frac := math.Modf(val.Float())
+		t = time.Unix(int64(sec), int64(frac*1e9))
+	default:
+		return unsupportedCoerceError(val, dst)
+	}
+	dst.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// 解析时间字符串
+func parseTime(str string) (t time.Time, err error) {
+	for _, layout := range timeLayouts {
+		if layout == time.RFC3339Nano {
+			t, err = time.Parse(layout, str)
+		} else {
+			t, err = time.ParseInLocation(layout, str, time.Local)
+		}
+		if err == nil {
+			return
+		}
+	}
+	err = fmt.Errorf("时间%q格式错误", str)
+	return
+}
+
+// 将字符串转换后设置到反射值，非字符串类型的空字符串保持零值（如未填写的数字输入框）
+func setStringValue(value reflect.Value, str string) error {
+	if str == "" && value.Kind() != reflect.String && value.Kind() != reflect.Interface {
+		return nil
+	}
+	if value.Type() == timeType {
+		t, err := parseTime(str)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if value.CanAddr() && value.Addr().Type().Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str))
+	}
+	switch value.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+		if err := setStringValue(elem.Elem(), str); err != nil {
+			return err
+		}
+		value.Set(elem)
+	case reflect.Interface:
+		if value.NumMethod() != 0 {
+			return fmt.Errorf("不支持的类型%v", value.Type())
+		}
+		value.Set(reflect.ValueOf(str))
+	case reflect.String:
+		value.SetString(str)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("不支持的类型%v", value.Type())
+	}
+	return nil
+}
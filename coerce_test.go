@@ -0,0 +1,136 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCoerceValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		typ   reflect.Type
+		want  interface{}
+	}{
+		{float64(3), reflect.TypeOf(int(0)), 3},
+		{"12", reflect.TypeOf(int(0)), 12},
+		{" ", reflect.TypeOf(""), " "},
+		{"", reflect.TypeOf(int(0)), 0},
+		{json.Number("3"), reflect.TypeOf(int64(0)), int64(3)},
+		{int64(127), reflect.TypeOf(int8(0)), int8(127)},
+		{uint64(255), reflect.TypeOf(uint8(0)), uint8(255)},
+		{float64(2), reflect.TypeOf(uint(0)), uint(2)},
+		{"true", reflect.TypeOf(false), true},
+		{nil, reflect.TypeOf(int(0)), 0},
+		{[]interface{}{"1", float64(2)}, reflect.TypeOf([]int{}), []int{1, 2}},
+		{int64(0), reflect.TypeOf(time.Time{}), time.Unix(0, 0)},
+	}
+	for _, test := range tests {
+		result, err := coerceValue(test.value, test.typ)
+		if err != nil {
+			t.Errorf("coerceValue(%#v, %v)返回错误：%v", test.value, test.typ, err)
+			continue
+		}
+		if !reflect.DeepEqual(result.Interface(), test.want) {
+			t.Errorf("coerceValue(%#v, %v) = %#v，期望 %#v", test.value, test.typ, result.Interface(), test.want)
+		}
+	}
+
+	errTests := []struct {
+		value interface{}
+		typ   reflect.Type
+	}{
+		// 转换失败
+		{"abc", reflect.TypeOf(int(0))},
+		{"1.5", reflect.TypeOf(int(0))},
+		{"yes", reflect.TypeOf(false)},
+		{"2024-13-01", reflect.TypeOf(time.Time{})},
+		{true, reflect.TypeOf(int(0))},
+		{[]interface{}{"1", "a"}, reflect.TypeOf([]int{})},
+		{float64(1.5), reflect.TypeOf(int(0))},
+		// 溢出
+		{float64(300), reflect.TypeOf(int8(0))},
+		{"300", reflect.TypeOf(int8(0))},
+		{int64(-129), reflect.TypeOf(int8(0))},
+		{int64(-1), reflect.TypeOf(uint(0))},
+		{float64(-1), reflect.TypeOf(uint(0))},
+		{"-1", reflect.TypeOf(uint(0))},
+		{uint64(math.MaxUint64), reflect.TypeOf(int64(0))},
+		{float64(1e20), reflect.TypeOf(int64(0))},
+		{float64(math.MaxFloat64), reflect.TypeOf(float32(0))},
+	}
+	for _, test := range errTests {
+		if result, err := coerceValue(test.value, test.typ); err == nil {
+			t.Errorf("coerceValue(%#v, %v) = %#v，期望返回错误", test.value, test.typ, result.Interface())
+		}
+	}
+}
+
+type coerceTestOrder struct {
+	Validator
+	Name     string `json:"name" validate:"required" title:"名称"`
+	Quantity int8   `json:"quantity" validate:"required" title:"数量"`
+	Age      int    `json:"age" title:"年龄"`
+	Stock    uint   `json:"stock" title:"库存"`
+}
+
+func (o *coerceTestOrder) DefineScenes() map[string][]string {
+	return map[string][]string{"name": {"name"}}
+}
+
+func TestCoerceCheckError(t *testing.T) {
+	tests := []struct {
+		scene  string
+		datas  map[string]interface{}
+		fields []string
+	}{
+		// 验证字段转换失败、溢出
+		{"", map[string]interface{}{"name": "a", "quantity": float64(300)}, []string{"quantity"}},
+		{"", map[string]interface{}{"name": "a", "quantity": "abc"}, []string{"quantity"}},
+		// 未定义验证规则的字段
+		{"", map[string]interface{}{"name": "a", "quantity": 1, "age": "abc"}, []string{"age"}},
+		{"", map[string]interface{}{"name": "a", "quantity": 1, "stock": float64(-1)}, []string{"stock"}},
+		// 不在验证场景中的字段
+		{"name", map[string]interface{}{"name": "a", "quantity": float64(1.5), "age": "abc"}, []string{"age", "quantity"}},
+		{"name", map[string]interface{}{"name": "a", "quantity": float64(1)}, nil},
+	}
+	for _, test := range tests {
+		for _, batch := range []bool{true, false} {
+			order := &coerceTestOrder{}
+			order.InitValidator(order)
+			order.SetCoerce(true)
+			if err := order.SetDatas(test.datas); err != nil {
+				t.Fatalf("SetDatas(%v)返回错误：%v", test.datas, err)
+			}
+			err := order.CheckScene(test.scene, WithBatch(batch))
+			if len(test.fields) == 0 {
+				if err != nil {
+					t.Errorf("CheckScene(%q, %v)返回错误：%v", test.scene, test.datas, err)
+				}
+				continue
+			}
+			if !IsValidationError(err) || errors.Is(err, ErrSystem) {
+				t.Errorf("CheckScene(%q, %v)返回错误 %v，期望验证错误", test.scene, test.datas, err)
+				continue
+			}
+			var fieldErr *ValidationError
+			if !errors.As(err, &fieldErr) || fieldErr.Rule != "type" || fieldErr.Field != test.fields[0] {
+				t.Errorf("CheckScene(%q, %v)返回错误 %#v，期望字段%s的type错误", test.scene, test.datas, fieldErr, test.fields[0])
+			}
+			if errs, ok := err.(*Errors); batch && (!ok || !reflect.DeepEqual(errs.Fields(), test.fields)) {
+				t.Errorf("CheckScene(%q, %v)批量验证返回错误 %v，期望字段%v", test.scene, test.datas, err, test.fields)
+			}
+		}
+	}
+}
+
+func TestSchemaCoerceError(t *testing.T) {
+	schema := MustNewSchema(&coerceTestOrder{})
+	result := schema.Validate("name", map[string]interface{}{"name": "a", "age": "abc"}, WithCoerce(true))
+	if result.Valid || result.Err == nil || len(result.Errors) != 1 || result.Errors[0].Field != "age" || result.Errors[0].Rule != "type" {
+		t.Errorf("Validate返回 %v %+v，期望字段age的type错误", result.Err, result.Errors)
+	}
+}
@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// 各验证场景的默认值，键为验证场景，“*”为其他验证场景的默认值，如：
+// validate.SceneDefaults{"create": 1, "*": 0}
+type SceneDefaults map[string]interface{}
+
+// 获取默认值，未定义当前验证场景的默认值时返回false
+// 默认值为func() interface{}时返回其调用结果（如：time.Now的包装函数）
+func resolveDefault(value interface{}, scene string) (interface{}, bool) {
+	if sceneDefaults, ok := value.(SceneDefaults); ok {
+		if value, ok = sceneDefaults[scene]; !ok {
+			if value, ok = sceneDefaults["*"]; !ok {
+				return nil, false
+			}
+		}
+	}
+	if fn, ok := value.(func() interface{}); ok {
+		value = fn()
+	}
+	return value, true
+}
+
+// 验证前设置默认值，数据未传入（不存在、结构体属性初始化的零值）或为nil时设置，设置后的值同步到对应的json标签属性上
+func (v *Validator) defaultDatas(datas map[string]interface{}) (err error) {
+	defaults, err := v.GetDefaults()
+	if err != nil || len(defaults) == 0 {
+		return
+	}
+	// 按字段排序，保证默认值函数的调用顺序一致
+	dataKeys := make([]string, 0, len(defaults))
+	for dataKey := range defaults {
+		dataKeys = append(dataKeys, dataKey)
+	}
+	sort.Strings(dataKeys)
+
+	defaulted := false
+	for _, dataKey := range dataKeys {
+		for _, data := range getPathValues(datas, dataKey) {
+			// 数据存在且不为nil时不设置（如传入的false、空字符串），结构体属性初始化的零值数据除外
+			if data.Exists && !isNilValue(data.Value) && !v.zeroKeys[data.Path] {
+				continue
+			}
+			// 上级数据不存在时不设置，如：items.0.qty的上级数据items.0不存在
+			if index := strings.LastIndex(data.Path, "."); index != -1 && isNestedPath(dataKey) {
+				if _, exists := getValueByPath(datas, data.Path[:index]); !exists {
+					continue
+				}
+			}
+			value, ok := resolveDefault(defaults[dataKey], v.Scene)
+			if !ok {
+				continue
+			}
+			// 默认值无法设置时（如类型不能赋值给属性类型）不设置
+			if !v.setDataValue(datas, data.Path, value) {
+				continue
+			}
+			delete(v.zeroKeys, data.Path)
+			defaulted = true
+		}
+	}
+	// 默认值同步到属性上
+	if defaulted {
+		err = v.SetDatas(datas)
+	}
+	return
+}
+
+// 判断值是否为nil（含nil指针）
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
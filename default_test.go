@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"testing"
+)
+
+type defaultTestArticle struct {
+	Validator
+	Title  string `json:"title" title:"标题"`
+	Sort   int    `json:"sort" title:"排序"`
+	Status int    `json:"status" title:"状态"`
+	Top    bool   `json:"top" title:"置顶"`
+}
+
+func (a *defaultTestArticle) DefineDefaults() map[string]interface{} {
+	return map[string]interface{}{"sort": 50, "status": "1", "top": true}
+}
+
+func TestDefaultSuppliedZeroValue(t *testing.T) {
+	// 未传入的零值属性设置默认值
+	article := &defaultTestArticle{}
+	article.InitValidator(article)
+	if err := article.Check(); err != nil {
+		t.Fatalf("Check返回错误：%v", err)
+	}
+	if article.Sort != 50 || !article.Top {
+		t.Errorf("Sort = %d，Top = %v，期望设置默认值", article.Sort, article.Top)
+	}
+
+	// GetDatas获取后修改再传入的数据视为已传入
+	article = &defaultTestArticle{}
+	article.InitValidator(article)
+	datas, _ := article.GetDatas()
+	datas["sort"] = 0
+	datas["top"] = false
+	if err := article.SetDatas(datas); err != nil {
+		t.Fatalf("SetDatas返回错误：%v", err)
+	}
+	if err := article.Check(); err != nil {
+		t.Fatalf("Check返回错误：%v", err)
+	}
+	if article.Sort != 0 || article.Top {
+		t.Errorf("Sort = %d，Top = %v，期望保持传入的零值", article.Sort, article.Top)
+	}
+}
+
+func TestDefaultUnassignableValue(t *testing.T) {
+	article := &defaultTestArticle{}
+	article.InitValidator(article)
+	datas := map[string]interface{}{"title": "a"}
+	article.SetDatas(datas)
+	// 默认值类型不能赋值给属性类型时不设置
+	if err := article.Check(); err != nil {
+		t.Fatalf("Check返回错误：%v", err)
+	}
+	if _, ok := datas["status"]; ok || article.Status != 0 {
+		t.Errorf("Status = %d，数据 = %#v，期望不设置默认值", article.Status, datas["status"])
+	}
+
+	// 开启类型转换时转换为属性类型
+	article = &defaultTestArticle{}
+	article.InitValidator(article)
+	article.SetCoerce(true)
+	article.SetDatas(map[string]interface{}{"title": "a"})
+	if err := article.Check(); err != nil {
+		t.Fatalf("Check返回错误：%v", err)
+	}
+	if article.Status != 1 {
+		t.Errorf("Status = %d，期望 1", article.Status)
+	}
+}
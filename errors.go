@@ -0,0 +1,310 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 验证错误集合（批量验证时返回）
+type Errors struct {
+	fields []string         // 验证失败的字段（按添加顺序）
+	errs   map[string]error // 字段对应的验证错误
+}
+
+// 添加字段验证错误
+func (e *Errors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+	if e.errs == nil {
+		e.errs = map[string]error{}
+	}
+	if _, ok := e.errs[field]; !ok {
+		e.fields = append(e.fields, field)
+	}
+	e.errs[field] = err
+}
+
+// 判断字段是否验证失败
+func (e *Errors) Has(field string) bool {
+	_, ok := e.errs[field]
+	return ok
+}
+
+// 获取字段验证错误
+func (e *Errors) Get(field string) error {
+	return e.errs[field]
+}
+
+// 获取验证失败的字段
+func (e *Errors) Fields() []string {
+	fields := make([]string, len(e.fields))
+	copy(fields, e.fields)
+	return fields
+}
+
+// 获取所有字段验证错误
+func (e *Errors) All() map[string]error {
+	errs := make(map[string]error, len(e.errs))
+	for k, v := range e.errs {
+		errs[k] = v
+	}
+	return errs
+}
+
+// 验证失败的字段数量
+func (e *Errors) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.fields)
+}
+
+// 获取第一个验证错误
+func (e *Errors) First() error {
+	if e.Len() == 0 {
+		return nil
+	}
+	return e.errs[e.fields[0]]
+}
+
+// 判断是否为验证失败错误，或任一字段验证错误是否匹配（支持Go 1.20以下的errors.Is）
+func (e *Errors) Is(target error) bool {
+	if target == ErrValidation {
+		return true
+	}
+	for _, field := range e.fields {
+		if errors.Is(e.errs[field], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// 按添加顺序查找第一个匹配的字段验证错误（支持Go 1.20以下的errors.As，如获取*ValidationError）
+func (e *Errors) As(target interface{}) bool {
+	for _, field := range e.fields {
+		if errors.As(e.errs[field], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// 错误信息，多个错误以“；”间隔
+func (e *Errors) Error() string {
+	msgs := make([]string, 0, len(e.fields))
+	for _, field := range e.fields {
+		msgs = append(msgs, e.errs[field].Error())
+	}
+	return strings.Join(msgs, "；")
+}
+
+// 获取所有字段验证错误（按添加顺序，非*ValidationError错误会被忽略）
+func (e *Errors) ValidationErrors() []*ValidationError {
+	list := make([]*ValidationError, 0, len(e.fields))
+	for _, field := range e.fields {
+		var ve *ValidationError
+		if errors.As(e.errs[field], &ve) {
+			list = append(list, ve)
+		}
+	}
+	return list
+}
+
+// 所有字段验证错误（Go 1.20及以上的多错误解包）
+func (e *Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e.fields))
+	for _, field := range e.fields {
+		errs = append(errs, e.errs[field])
+	}
+	return errs
+}
+
+// 字段验证失败错误
+type ValidationError struct {
+	Field   string      // 字段
+	Title   string      // 字段标题
+	Rule    string      // 验证规则名称（闭包验证方法为func）
+	Param   string      // 验证规则参数
+	Value   interface{} // 验证的值
+	Message string      // 错误信息
+	Err     error       // 验证规则返回的原始错误
+}
+
+// 错误信息
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// 验证规则返回的原始错误
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// 判断是否为验证失败错误
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// 创建字段验证失败错误
+// 如果err已经是*ValidationError，则补全其中未设置的字段信息
+func newValidationError(field string, title string, rule string, param string, value interface{}, err error) *ValidationError {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		if ve.Field == "" {
+			ve.Field = field
+		}
+		if ve.Title == "" {
+			ve.Title = title
+		}
+		if ve.Rule == "" {
+			ve.Rule = rule
+			ve.Param = param
+		}
+		if ve.Value == nil {
+			ve.Value = value
+		}
+		if ve.Message == "" && ve.Err != nil {
+			ve.Message = ve.Err.Error()
+		}
+		return ve
+	}
+	return &ValidationError{
+		Field:   field,
+		Title:   title,
+		Rule:    rule,
+		Param:   param,
+		Value:   value,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
+
+var (
+	ErrSystem     = errors.New("验证器系统错误") // 系统错误（可通过errors.Is判断）
+	ErrValidation = errors.New("数据验证失败")  // 验证失败错误（可通过errors.Is判断）
+)
+
+// 系统错误（验证器定义、验证规则配置等错误）
+type SystemError struct {
+	Prefix  string // 错误前缀
+	Message string // 错误信息
+	Err     error  // 原始错误（如验证规则字符串语法错误*RuleSyntaxError）
+}
+
+// 错误信息
+func (e *SystemError) Error() string {
+	return e.Prefix + e.Message
+}
+
+// 判断是否为系统错误
+func (e *SystemError) Is(target error) bool {
+	return target == ErrSystem
+}
+
+// 获取原始错误
+func (e *SystemError) Unwrap() error {
+	return e.Err
+}
+
+// 创建系统错误
+func newSystemError(message string) *SystemError {
+	return &SystemError{Message: message}
+}
+
+// 判断是否为系统错误
+func IsSystemError(err error) bool {
+	return errors.Is(err, ErrSystem)
+}
+
+// 判断是否为上下文取消、超时错误（验证中止）
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// 判断是否为验证失败错误
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// 合并验证错误，*Errors合并其中所有字段错误，其他错误根据*ValidationError的字段添加
+func (e *Errors) Merge(err error) {
+	if err == nil {
+		return
+	}
+	if errs, ok := err.(*Errors); ok {
+		for _, field := range errs.fields {
+			e.Add(field, errs.errs[field])
+		}
+		return
+	}
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		e.Add(ve.Field, err)
+		return
+	}
+	e.Add("", err)
+}
+
+// 为验证错误的字段添加路径前缀，如：sku => items.0.sku
+func prefixError(prefix string, err error) error {
+	if errs, ok := err.(*Errors); ok {
+		newErrs := &Errors{}
+		for _, field := range errs.fields {
+			newErrs.Add(joinPath(prefix, field), prefixError(prefix, errs.errs[field]))
+		}
+		return newErrs
+	}
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		newVe := *ve
+		newVe.Field = joinPath(prefix, ve.Field)
+		return &newVe
+	}
+	return &ValidationError{Field: prefix, Message: err.Error(), Err: err}
+}
+
+// 验证规则错误，提示信息根据验证时的语言翻译
+// 自定义规则可返回此错误，并通过RegisterMessages注册各语言的提示信息模板
+type RuleError struct {
+	Key  string                 // 提示信息键
+	Vars map[string]interface{} // 提示信息模板的占位符变量
+}
+
+// 创建验证规则错误
+func NewRuleError(key string, vars map[string]interface{}) *RuleError {
+	return &RuleError{Key: key, Vars: vars}
+}
+
+// 创建验证规则错误，vars为占位符变量的键值对，如：ruleError("max", title, "max", 20)
+func ruleError(key string, title string, vars ...interface{}) *RuleError {
+	e := &RuleError{Key: key, Vars: map[string]interface{}{"title": title}}
+	for i := 0; i+1 < len(vars); i += 2 {
+		if name, ok := vars[i].(string); ok {
+			e.Vars[name] = vars[i+1]
+		}
+	}
+	return e
+}
+
+// 错误信息（默认语言）
+func (e *RuleError) Error() string {
+	return e.Message("")
+}
+
+// 获取指定语言的错误信息
+func (e *RuleError) Message(locale string) string {
+	message := translate(locale, e.Key)
+	if message == "" {
+		message = e.Key
+	}
+	return renderTemplate(message, func(name string) (string, bool) {
+		if value, ok := e.Vars[name]; ok {
+			return fmt.Sprintf("%v", value), true
+		}
+		return "", false
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type Article struct {
+	validate.Validator
+	Tags    []string          `json:"tags"`
+	Images  []string          `json:"images"`
+	Scores  map[string]int    `json:"scores"`
+	Options map[string]string `json:"options"`
+}
+
+func (a *Article) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		// 验证切片本身
+		"tags": "required|array",
+		// 验证切片的每个元素，替代arrayXxx等组合规则
+		"tags.*":   "required|alphaDash|max:10",
+		"images.*": "url",
+		// 验证map的每个值
+		"scores.*":  "between:0,100",
+		"options.*": "in:on,off",
+	}
+}
+
+func (a *Article) DefineTitles() map[string]string {
+	return map[string]string{
+		"tags":      "标签",
+		"images":    "图片",
+		"scores":    "评分",
+		"options.*": "选项",
+	}
+}
+
+func main() {
+	article := &Article{
+		Tags:    []string{"golang", "", "go validate", "validator-framework"},
+		Images:  []string{"https://example.com/1.png", "example.com/2.png"},
+		Scores:  map[string]int{"math": 98, "english": 120},
+		Options: map[string]string{"comment": "on", "share": "yes"},
+	}
+	article.InitValidator(article)
+	err := article.Check(validate.WithBatch(true))
+	if errs, ok := err.(*validate.Errors); ok {
+		for _, field := range errs.Fields() {
+			fmt.Printf("%s：%v\r\n", field, errs.Get(field))
+		}
+	} else if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserRegister struct {
+	validate.Validator
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Mobile   string `json:"mobile"`
+}
+
+func (u *UserRegister) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"username": "required|alphaNum",
+		"password": "required|length:6,12",
+		"mobile":   "required|mobile",
+	}
+}
+
+func (u *UserRegister) DefineTitles() map[string]string {
+	return map[string]string{
+		"username": "用户名",
+		"password": "密码",
+		"mobile":   "手机号",
+	}
+}
+
+func main() {
+	// 单次验证开启批量验证
+	user := &UserRegister{Username: "管理员", Password: "123", Mobile: "123"}
+	user.InitValidator(user)
+	err := user.Check(validate.WithBatch(true))
+	var errs *validate.Errors
+	if errors.As(err, &errs) {
+		for _, field := range errs.Fields() {
+			fmt.Printf("字段%s验证失败！%v\r\n", field, errs.Get(field))
+		}
+	} else if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+
+	// 验证器开启批量验证
+	user2 := &UserRegister{Username: "admin"}
+	user2.InitValidator(user2)
+	user2.SetBatch(true)
+	err = user2.Check()
+	if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+}
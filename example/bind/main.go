@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/worklz/go-validate"
+)
+
+type ArticleCreate struct {
+	validate.Validator
+	Title     string                `json:"title" validate:"required|max:20" title:"标题"`
+	CateId    int                   `json:"cate_id" validate:"required|positiveInt" title:"分类"`
+	Tags      []string              `json:"tags" title:"标签"`
+	PublishAt time.Time             `json:"publish_at" title:"发布时间"`
+	Cover     *multipart.FileHeader `json:"cover" title:"封面"`
+}
+
+func (a *ArticleCreate) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"tags.*": "alphaDash",
+	}
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	article := &ArticleCreate{}
+	err := validate.Bind(r, article, "", validate.WithBatch(true))
+	var bindErr *validate.BindError
+	switch {
+	case errors.Is(err, validate.ErrUnsupportedMediaType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "请求数据格式不支持：%v", err)
+	case errors.As(err, &bindErr):
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "请求数据错误：%v", err)
+	case validate.IsValidationError(err):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprintf(w, "验证失败：%v", err)
+	case err != nil:
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "系统错误")
+	default:
+		cover := ""
+		if article.Cover != nil {
+			cover = article.Cover.Filename
+		}
+		fmt.Fprintf(w, "验证通过：标题=%s，分类=%d，标签=%v，发布时间=%s，封面=%s", article.Title, article.CateId, article.Tags, article.PublishAt.Format("2006-01-02"), cover)
+	}
+}
+
+func serve(r *http.Request) {
+	w := httptest.NewRecorder()
+	handler(w, r)
+	fmt.Printf("[%d] %s\r\n", w.Code, w.Body.String())
+}
+
+func main() {
+	// json请求体
+	r := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{"title":"Go验证器","cate_id":3,"tags":["go","validate"],"publish_at":"2024-01-01T00:00:00Z"}`))
+	r.Header.Set("Content-Type", "application/json")
+	serve(r)
+
+	// json请求体格式错误
+	r = httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{"title":"Go验证器","cate_id":"3"}`))
+	r.Header.Set("Content-Type", "application/json")
+	serve(r)
+
+	// json请求体包含多余的数据
+	r = httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{"title":"Go验证器","cate_id":3} {}`))
+	r.Header.Set("Content-Type", "application/json")
+	serve(r)
+
+	// 不支持的Content-Type
+	r = httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader("title=Go验证器"))
+	r.Header.Set("Content-Type", "text/plain")
+	serve(r)
+
+	// 表单
+	r = httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader("title=&cate_id=0&tags=go&tags=go+validate"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	serve(r)
+
+	// URL查询参数
+	r = httptest.NewRequest(http.MethodGet, "/articles?title=Go验证器&cate_id=abc", nil)
+	serve(r)
+
+	// multipart表单（含上传文件）
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	mw.WriteField("title", "Go验证器")
+	mw.WriteField("cate_id", "5")
+	mw.WriteField("publish_at", "2024-02-01T08:00:00+08:00")
+	fw, _ := mw.CreateFormFile("cover", "cover.png")
+	fw.Write([]byte("png"))
+	mw.Close()
+	r = httptest.NewRequest(http.MethodPost, "/articles", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	serve(r)
+}
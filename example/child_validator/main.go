@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+// 订单商品验证器
+type OrderItem struct {
+	validate.Validator
+	Sku    string `json:"sku" validate:"required|alphaDash" title:"商品编码"`
+	Num    int    `json:"num" validate:"required|positiveInt" title:"商品数量"`
+	Remark string `json:"remark" validate:"max:10" title:"备注"`
+}
+
+func (o *OrderItem) DefineScenes() map[string][]string {
+	return map[string][]string{
+		"create": {"sku", "num"},
+	}
+}
+
+// 收货地址验证器
+type Address struct {
+	validate.Validator
+	City   string `json:"city" validate:"required|chs" title:"城市"`
+	Detail string `json:"detail" validate:"required" title:"详细地址"`
+}
+
+// 订单验证器
+type Order struct {
+	validate.Validator
+	OrderNo string       `json:"order_no" validate:"required|alphaNum" title:"订单号"`
+	Address *Address     `json:"address" validate:"required" title:"收货地址"`
+	Items   []*OrderItem `json:"items" validate:"required" title:"商品"`
+	Gifts   []OrderItem  `json:"gifts" scene:"create"`
+}
+
+func (o *Order) DefineScenes() map[string][]string {
+	return map[string][]string{
+		// 子验证器使用同名的create场景验证
+		"create": {"order_no", "address", "items", "gifts"},
+	}
+}
+
+func main() {
+	order := &Order{
+		OrderNo: "NO20240101",
+		Address: &Address{City: "Shenzhen"},
+		Items: []*OrderItem{
+			{Sku: "A-01", Num: 1, Remark: "这是一个很长很长的备注信息"},
+			{Sku: "B 02"},
+		},
+		Gifts: []OrderItem{{Num: 1}},
+	}
+	order.InitValidator(order)
+	err := order.CheckScene("create", validate.WithBatch(true))
+	if errs, ok := err.(*validate.Errors); ok {
+		for _, field := range errs.Fields() {
+			fmt.Printf("%s：%v\r\n", field, errs.Get(field))
+		}
+	} else if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+
+	// 非批量验证返回第一个错误
+	err = order.Check()
+	if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	}
+}
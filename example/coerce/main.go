@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/worklz/go-validate"
+)
+
+type Order struct {
+	validate.Validator
+	Id       uint64    `json:"id" validate:"required" title:"订单ID"`
+	Quantity int8      `json:"quantity" validate:"required|between:1,100" title:"数量"`
+	Price    float64   `json:"price" validate:"required" title:"价格"`
+	Paid     *bool     `json:"paid" title:"是否已支付"`
+	Tags     []int     `json:"tags" title:"标签"`
+	Deadline time.Time `json:"deadline" validate:"required" title:"截止日期"`
+}
+
+// 验证器定义（可在多个goroutine中共用）
+var orderSchema = validate.MustNewSchema(&Order{})
+
+func main() {
+	// json解码的数字为float64、json.Number，字符串需转换为time.Time
+	bodies := []string{
+		`{"id": 1001, "quantity": 3, "price": "9.90", "paid": "true", "tags": [1, 2], "deadline": "2024-01-01"}`,
+		`{"id": 1002, "quantity": 300, "price": 9.9, "deadline": "2024-01-01 12:00:00"}`,
+		`{"id": -1, "quantity": 1.5, "price": 9.9, "deadline": "明天"}`,
+	}
+	for i, body := range bodies {
+		decoder := json.NewDecoder(strings.NewReader(body))
+		decoder.UseNumber()
+		datas := map[string]interface{}{}
+		decoder.Decode(&datas)
+		result := orderSchema.Validate("", datas, validate.WithCoerce(true), validate.WithBatch(true))
+		if !result.Valid {
+			fmt.Printf("请求%d验证失败！\r\n", i+1)
+			for _, fieldErr := range result.Errors {
+				fmt.Printf("  字段：%s，规则：%s，提示：%s，原因：%v\r\n", fieldErr.Field, fieldErr.Rule, fieldErr.Message, fieldErr.Err)
+			}
+			continue
+		}
+		order := result.Validator.(*Order)
+		fmt.Printf("请求%d验证通过，ID：%d，数量：%d，价格：%.2f，已支付：%v，标签：%v，截止日期：%s\r\n", i+1, order.Id, order.Quantity, order.Price, *order.Paid, order.Tags, order.Deadline.Format("2006-01-02"))
+	}
+
+	// 验证器实例开启类型转换（需在SetDatas前设置）
+	order := &Order{}
+	order.InitValidator(order)
+	order.SetCoerce(true)
+	order.SetDatas(map[string]interface{}{"id": float64(1003), "quantity": float64(2), "price": 1, "deadline": int64(1704067200)})
+	if err := order.Check(); err != nil {
+		fmt.Println("验证失败！", err)
+		return
+	}
+	fmt.Printf("验证通过，ID：%d，数量：%d，截止日期：%s\r\n", order.Id, order.Quantity, order.Deadline.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/worklz/go-validate"
+)
+
+// 模拟查询数据库（耗时100毫秒）
+func queryUsernameExists(ctx context.Context, username string) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(100 * time.Millisecond):
+		return username == "admin", nil
+	}
+}
+
+type UserRegister struct {
+	validate.Validator
+	Username   string `json:"username" validate:"required|alphaNum|uniqueUsername" title:"用户名"`
+	Nickname   string `json:"nickname" validate:"required|IsNicknameAllowed" title:"昵称"`
+	InviteCode string `json:"invite_code" title:"邀请码"`
+}
+
+func (u *UserRegister) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		// 闭包规则可获取上下文
+		"invite_code": func(ctx context.Context, value interface{}, datas map[string]interface{}, title string) error {
+			if value != "VIP" {
+				return errors.New(title + "无效")
+			}
+			return nil
+		},
+	}
+}
+
+// 验证器方法规则第1个参数可定义为上下文
+func (u *UserRegister) IsNicknameAllowed(ctx context.Context, value interface{}, param string, datas map[string]interface{}, title string) error {
+	if value == "root" {
+		return errors.New(title + "不可用")
+	}
+	return nil
+}
+
+func main() {
+	// 注册可获取上下文的规则
+	validate.RegisterRule(validate.Rule{
+		Name: "uniqueUsername",
+		CtxFun: func(ctx context.Context, value interface{}, param string, datas map[string]interface{}, title string) error {
+			exists, err := queryUsernameExists(ctx, fmt.Sprintf("%v", value))
+			if err != nil {
+				return err
+			}
+			if exists {
+				return errors.New(title + "已存在")
+			}
+			return nil
+		},
+	})
+
+	user := &UserRegister{Username: "admin", Nickname: "root", InviteCode: "abc"}
+	user.InitValidator(user)
+	err := user.CheckContext(context.Background(), validate.WithBatch(true))
+	fmt.Printf("注册验证：%v\r\n", err)
+
+	// 超时时中止验证
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = user.CheckContext(ctx, validate.WithBatch(true))
+	fmt.Printf("注册验证（超时）：%v，是否超时：%v\r\n", err, errors.Is(err, context.DeadlineExceeded))
+
+	// 验证单个数据
+	ctx, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	err = validate.CheckVarContext(ctx, "admin", "required|uniqueUsername", "用户名", nil)
+	fmt.Printf("用户名验证（已取消）：%v，是否取消：%v\r\n", err, errors.Is(err, context.Canceled))
+}
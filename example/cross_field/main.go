@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserPassword struct {
+	validate.Validator
+	OldPassword     string `json:"old_password" validate:"required" title:"原密码"`
+	Password        string `json:"password" validate:"required|length:6,12|different:old_password" title:"新密码"`
+	ConfirmPassword string `json:"confirm_password" validate:"required|confirm:password" title:"确认密码"`
+}
+
+type Activity struct {
+	validate.Validator
+	StartTime string `json:"start_time" validate:"required|datetime" title:"开始时间"`
+	EndTime   string `json:"end_time" validate:"required|datetime|gtField:start_time" title:"结束时间"`
+	MinNum    int    `json:"min_num" validate:"required" title:"最少人数"`
+	MaxNum    int    `json:"max_num" validate:"required|gteField:min_num" title:"最多人数"`
+}
+
+func main() {
+	user := &UserPassword{OldPassword: "123456", Password: "123456", ConfirmPassword: "654321"}
+	user.InitValidator(user)
+	err := user.Check(validate.WithBatch(true))
+	if err != nil {
+		fmt.Printf("修改密码验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("修改密码验证通过")
+	}
+
+	activity := &Activity{StartTime: "2024-01-02 00:00:00", EndTime: "2024-01-01 00:00:00", MinNum: 10, MaxNum: 5}
+	activity.InitValidator(activity)
+	err = activity.Check(validate.WithBatch(true))
+	if err != nil {
+		fmt.Printf("活动验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("活动验证通过")
+	}
+}
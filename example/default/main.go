@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/worklz/go-validate"
+)
+
+type Article struct {
+	validate.Validator
+	Title     string        `json:"title" validate:"required|max:50" title:"标题"`
+	Status    string        `json:"status" validate:"required|in:draft,published" title:"状态"`
+	Sort      int           `json:"sort" validate:"between:0,100" title:"排序"`
+	Author    string        `json:"author" validate:"trim|required" title:"作者"`
+	CreatedAt time.Time     `json:"created_at" validate:"required" title:"创建时间"`
+	Items     []interface{} `json:"items" title:"附件"`
+}
+
+func (a *Article) DefineScenes() map[string][]string {
+	return map[string][]string{
+		"create": {"title", "status", "sort", "author", "created_at"},
+		"draft":  {"title", "status", "author"},
+	}
+}
+
+// 定义字段默认值（数据未传入或为nil时设置）
+func (a *Article) DefineDefaults() map[string]interface{} {
+	return map[string]interface{}{
+		// 各验证场景的默认值
+		"status": validate.SceneDefaults{"create": "published", "*": "draft"},
+		"sort":   50,
+		// 默认值先于过滤器设置，过滤器、验证规则获取的是设置默认值后的数据
+		"author": " 匿名 ",
+		// 验证时调用获取默认值
+		"created_at": func() interface{} { return time.Now() },
+		// 多级路径
+		"items.*.type": "file",
+	}
+}
+
+func main() {
+	for _, scene := range []string{"create", "draft"} {
+		article := &Article{}
+		article.InitValidator(article)
+		article.SetDatas(map[string]interface{}{
+			"title": "Go验证器",
+			// 传入的数据不设置默认值（如：0、false、空字符串）
+			"sort":  0,
+			"items": []interface{}{map[string]interface{}{"name": "a.png"}, map[string]interface{}{"name": "b.mp4", "type": "video"}},
+		})
+		result := article.Validate(scene)
+		if !result.Valid {
+			fmt.Printf("场景%s验证失败！%v\r\n", scene, result.Err)
+			continue
+		}
+		fmt.Printf("场景%s验证通过，状态：%s，排序：%d，作者：%q，已设置创建时间：%v，附件：%v\r\n", scene, article.Status, article.Sort, article.Author, !article.CreatedAt.IsZero(), article.Items)
+	}
+
+	// 传入的数据不设置默认值
+	article := &Article{}
+	article.InitValidator(article)
+	article.SetDatas(map[string]interface{}{"title": "Go验证器", "status": "unknown"})
+	if err := article.CheckScene("create"); err != nil {
+		fmt.Println("验证失败！", err)
+	}
+}
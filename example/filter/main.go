@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserRegister struct {
+	validate.Validator
+	Email    string        `json:"email" validate:"trim|lower|required|email" title:"邮箱"`
+	Nickname string        `json:"nickname" validate:"trim|stripTags|required|max:20" title:"昵称"`
+	Intro    string        `json:"intro" title:"简介"`
+	Age      interface{}   `json:"age" validate:"toInt|required|between:1,150" title:"年龄"`
+	Tags     []interface{} `json:"tags" title:"标签"`
+}
+
+// 定义验证前的数据过滤器（字段可不定义验证规则，支持多级路径）
+func (u *UserRegister) DefineFilters() map[string]string {
+	return map[string]string{
+		"intro":  "trim|stripTags",
+		"tags.*": "trim|upper",
+	}
+}
+
+func init() {
+	// 注册过滤器
+	err := validate.RegisterFilter("collapseSpace", func(value interface{}) (interface{}, error) {
+		if s, ok := value.(string); ok {
+			return strings.Join(strings.Fields(s), " "), nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	requests := []map[string]interface{}{
+		{"email": "  Admin@Example.COM ", "nickname": " <b>管理员</b> ", "intro": " <p>你好</p> ", "age": "18", "tags": []interface{}{" go ", "validate"}},
+		{"email": "   ", "nickname": "<i></i>", "age": "abc"},
+	}
+	for i, datas := range requests {
+		user := &UserRegister{}
+		user.InitValidator(user)
+		user.SetDatas(datas)
+		if err := user.Check(validate.WithBatch(true)); err != nil {
+			fmt.Printf("请求%d验证失败！%v\r\n", i+1, err)
+			continue
+		}
+		// 过滤后的值同步到属性上
+		fmt.Printf("请求%d验证通过，邮箱：%q，昵称：%q，简介：%q，年龄：%v（%T），标签：%q\r\n", i+1, user.Email, user.Nickname, user.Intro, user.Age, user.Age, user.Tags)
+	}
+
+	// 验证单个变量
+	err := validate.CheckVar("  hello   world  ", "collapseSpace|required|max:11", "标题", nil)
+	fmt.Println("验证单个变量：", err)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserRegister struct {
+	validate.Validator
+	Username string   `json:"username" validate:"required|alphaNum|max:20|notAdmin" title:"Username"`
+	Password string   `json:"password" validate:"required|length:6,12" title:"Password"`
+	Tags     []string `json:"tags" title:"Tags"`
+	Status   string   `json:"status" validate:"required|status" title:"Status"`
+}
+
+func (u *UserRegister) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"tags.*": "alphaDash",
+	}
+}
+
+func main() {
+	validate.RegisterRules([]validate.Rule{
+		// 自定义规则返回RuleError，通过RegisterMessages注册各语言的提示信息
+		{Name: "notAdmin", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if str, ok := value.(string); ok && strings.EqualFold(str, "admin") {
+				return validate.NewRuleError("notAdmin", map[string]interface{}{"title": title, "name": str})
+			}
+			return nil
+		}},
+		// 自定义规则定义各语言的提示信息
+		{Name: "status", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if value != "on" && value != "off" {
+				return fmt.Errorf("%s错误", title)
+			}
+			return nil
+		}, Messages: map[string]string{
+			"en": "{title} must be on or off",
+		}},
+	})
+	validate.RegisterMessages("zh-CN", map[string]string{"notAdmin": "{title}不能为{name}"})
+	validate.RegisterMessages("en", map[string]string{"notAdmin": "{title} may not be {name}"})
+	// 新增语言
+	validate.RegisterMessages("ja", map[string]string{
+		"required": "{title}は必須です",
+		"length":   "{title}は{length}文字で入力してください",
+	})
+
+	user := &UserRegister{Username: "admin", Password: "123", Tags: []string{"go", "go validate"}, Status: "unknown"}
+	user.InitValidator(user)
+	for _, locale := range []string{"zh-CN", "en-US", "ja"} {
+		err := user.Check(validate.WithBatch(true), validate.WithLocale(locale))
+		fmt.Printf("[%s] %v\r\n", locale, err)
+	}
+
+	// 验证器设置语言
+	user.SetLocale("en")
+	user.Password = ""
+	fmt.Printf("[en] %v\r\n", user.Check())
+
+	// 验证单个数据
+	err := validate.CheckVar("", "required", "Nickname", nil, validate.WithLocale("ja"))
+	fmt.Printf("[ja] %v\r\n", err)
+}
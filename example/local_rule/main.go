@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+// 订单模块
+type Order struct {
+	validate.Validator
+	Status string `json:"status" validate:"required|status" title:"订单状态"`
+}
+
+// 订单模块的局部验证规则（不影响其他验证器）
+func (o *Order) DefineLocalRules() []validate.Rule {
+	return []validate.Rule{
+		{Name: "status", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if value != "paid" && value != "shipped" {
+				return errors.New(title + "只能是paid或shipped")
+			}
+			return nil
+		}},
+	}
+}
+
+// 用户模块
+type User struct {
+	validate.Validator
+	Status string `json:"status" validate:"required|status" title:"用户状态"`
+}
+
+// 用户模块的局部验证规则（与订单模块的同名规则互不影响）
+func (u *User) DefineLocalRules() []validate.Rule {
+	return []validate.Rule{
+		{Name: "status", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if value != "enabled" && value != "disabled" {
+				return errors.New(title + "只能是enabled或disabled")
+			}
+			return nil
+		}},
+	}
+}
+
+func main() {
+	order := &Order{Status: "enabled"}
+	order.InitValidator(order)
+	fmt.Printf("订单验证：%v\r\n", order.Check())
+
+	user := &User{Status: "enabled"}
+	user.InitValidator(user)
+	fmt.Printf("用户验证：%v\r\n", user.Check())
+
+	// 单次验证使用的规则（优先于验证器的局部规则）
+	err := user.Check(validate.WithRules(validate.Rule{Name: "status", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+		return errors.New(title + "已停用")
+	}}))
+	fmt.Printf("用户验证（单次验证规则）：%v\r\n", err)
+
+	// 验证单个数据使用的规则注册表
+	registry := validate.NewRegistry()
+	registry.Register(validate.Rule{Name: "even", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+		if num, ok := value.(int); !ok || num%2 != 0 {
+			return errors.New(title + "需为偶数")
+		}
+		return nil
+	}})
+	err = validate.CheckVar(3, "required|even", "数量", nil, validate.WithRegistry(registry))
+	fmt.Printf("数量验证：%v\r\n", err)
+	err = validate.CheckVar(3, "required|even", "数量", nil)
+	fmt.Printf("数量验证（未使用注册表）：%v\r\n", err)
+}
@@ -33,6 +33,11 @@ func (u *UserLogin) DefineScenes() map[string][]string {
 	}
 }
 
+// 定义验证字段顺序（map数据无结构体属性定义顺序，未定义时按字段名称排序）
+func (u *UserLogin) DefineOrder() []string {
+	return []string{"username", "password", "captcha"}
+}
+
 func main() {
 	userLogin := &UserLogin{}
 	userLogin.InitValidator(userLogin)
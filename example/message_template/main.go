@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserRegister struct {
+	validate.Validator
+	Username string `json:"username" validate:"required|alphaNum" title:"用户名" msg:"alphaNum:{title}[{value}]只能是字母或数字"`
+	Password string `json:"password" validate:"required|length:6,12" title:"密码"`
+	Captcha  string `json:"captcha" validate:"required|IsCaptcha" title:"验证码"`
+	Age      int    `json:"age" title:"年龄"`
+	Nickname string `json:"nickname" validate:"required|chsAlphaNum" title:"昵称"`
+	Email    string `json:"email" validate:"required|email|max:30" title:"邮箱"`
+}
+
+func (u *UserRegister) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"age": func(value interface{}, datas map[string]interface{}, title string) error {
+			if age, ok := value.(int); ok && age < 18 {
+				return errors.New("未满18岁")
+			}
+			return nil
+		},
+	}
+}
+
+func (u *UserRegister) DefineMessages() map[string]string {
+	return map[string]string{
+		// 内置规则
+		"password.length": "{title}长度需为{param.0}-{param.1}位",
+		// 验证器方法规则
+		"captcha.IsCaptcha": "{title}错误，请重新输入",
+		// 闭包规则（规则名称为func）
+		"age.func": "{title}需满18岁，当前为{value}岁",
+		// 字段的所有规则
+		"email.*": "请输入正确的{title}",
+		// 所有字段的规则
+		"*.required": "请填写{title}",
+	}
+}
+
+func (u *UserRegister) IsCaptcha(value interface{}, param string, datas map[string]interface{}, title string) error {
+	if value != "1234" {
+		return errors.New(title + "只能为1234")
+	}
+	return nil
+}
+
+func main() {
+	user := &UserRegister{Username: "管理员", Password: "123", Captcha: "123", Age: 16, Email: "abc"}
+	user.InitValidator(user)
+	err := user.Check(validate.WithBatch(true))
+	if err != nil {
+		fmt.Printf("注册验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("注册验证通过")
+	}
+
+	// 验证单个数据
+	err = validate.CheckVar("abc", "required|length:6,12", "昵称", map[string]string{"length": "{title}长度需为{param}位"})
+	if err != nil {
+		fmt.Printf("昵称验证失败！%v\r\n", err)
+	}
+}
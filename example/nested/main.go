@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type Address struct {
+	Province string `json:"province"`
+	City     string `json:"city"`
+}
+
+type Item struct {
+	Sku string `json:"sku"`
+	Num int    `json:"num"`
+}
+
+type Order struct {
+	validate.Validator
+	Address Address                `json:"address"`
+	Items   []Item                 `json:"items"`
+	Extra   map[string]interface{} `json:"extra"`
+}
+
+func (o *Order) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"address.province": "required|chs",
+		"address.city":     "required|chs",
+		"items":            "required|array",
+		"items.*.sku":      "required|alphaDash",
+		"items.*.num":      "positiveInt",
+		"extra.remark":     "max:10",
+	}
+}
+
+func (o *Order) DefineTitles() map[string]string {
+	return map[string]string{
+		"address.province": "省份",
+		"address.city":     "城市",
+		"items.*.sku":      "商品编码",
+		"items.*.num":      "商品数量",
+		"extra.remark":     "备注",
+	}
+}
+
+func (o *Order) DefineMessages() map[string]string {
+	return map[string]string{
+		"items.*.sku.required": "请填写商品编码",
+		"items.1.sku.required": "请填写第2个商品的编码",
+	}
+}
+
+func main() {
+	order := &Order{
+		Address: Address{Province: "广东"},
+		Items:   []Item{{Sku: "A-01", Num: 1}, {Num: 2}, {Sku: "C 03", Num: 0}},
+		Extra:   map[string]interface{}{"remark": "这是一个很长很长的备注信息"},
+	}
+	order.InitValidator(order)
+	err := order.Check(validate.WithBatch(true))
+	if errs, ok := err.(*validate.Errors); ok {
+		for _, field := range errs.Fields() {
+			fmt.Printf("%s：%v\r\n", field, errs.Get(field))
+		}
+	} else if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+}
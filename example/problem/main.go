@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserRegister struct {
+	validate.Validator
+	Username string `json:"username" validate:"required|alphaNum" title:"用户名"`
+	Password string `json:"password" validate:"required|length:6,12" title:"密码"`
+	Age      int    `json:"age" validate:"required|IsAdult" title:"年龄"`
+}
+
+// 方法定义错误（系统错误）
+func (u *UserRegister) IsAdult(value interface{}) error {
+	return nil
+}
+
+func register(w http.ResponseWriter, r *http.Request) error {
+	user := &UserRegister{}
+	scene := r.URL.Query().Get("scene")
+	if err := validate.Bind(r, user, scene, validate.WithBatch(true)); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "注册成功：%s", user.Username)
+	return nil
+}
+
+func (u *UserRegister) DefineScenes() map[string][]string {
+	return map[string][]string{
+		"simple": {"username", "password"},
+	}
+}
+
+func main() {
+	// 自定义问题详情渲染器
+	renderer := &validate.ProblemRenderer{
+		Type:             "https://example.com/problems/validation",
+		ValidationStatus: http.StatusBadRequest,
+		SystemDetail:     "服务器繁忙，请稍后再试",
+		OnError: func(r *http.Request, err error) {
+			fmt.Printf("系统错误：%s %s %v\r\n", r.Method, r.URL.Path, err)
+		},
+	}
+	handler := renderer.Handler(register)
+
+	requests := []struct {
+		url  string
+		body string
+	}{
+		{"/register?scene=simple", `{"username":"admin","password":"123456"}`},
+		{"/register?scene=simple", `{"username":"管理员","password":"123"}`},
+		{"/register?scene=simple", `{"username":"admin","password":123456}`},
+		{"/register", `{"username":"admin","password":"123456","age":18}`},
+	}
+	for _, req := range requests {
+		r := httptest.NewRequest(http.MethodPost, req.url, strings.NewReader(req.body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		fmt.Printf("[%d] %s %s\r\n", w.Code, w.Header().Get("Content-Type"), strings.TrimSpace(w.Body.String()))
+	}
+
+	// 客户端已取消请求（不调用OnError）
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := httptest.NewRequest(http.MethodPost, "/register?scene=simple", strings.NewReader(`{"username":"admin","password":"123456"}`)).WithContext(ctx)
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	fmt.Printf("[%d] %s\r\n", w.Code, strings.TrimSpace(w.Body.String()))
+
+	// 使用默认问题详情渲染器
+	r = httptest.NewRequest(http.MethodPost, "/register?scene=simple", strings.NewReader(`{}`))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	validate.ProblemHandler(register).ServeHTTP(w, r)
+	fmt.Printf("[%d] %s\r\n", w.Code, strings.TrimSpace(w.Body.String()))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 
@@ -14,6 +15,7 @@ type UserLogin struct {
 func (u *UserLogin) DefineRules() map[string]interface{} {
 	return map[string]interface{}{
 		"username": "required|alphaNum",
+		"nickname": "letterOrDigit",
 		"password": "required",
 		"captcha":  "required",
 	}
@@ -22,34 +24,48 @@ func (u *UserLogin) DefineRules() map[string]interface{} {
 func (u *UserLogin) DefineTitles() map[string]string {
 	return map[string]string{
 		"username": "用户名",
+		"nickname": "昵称",
 		"password": "密码",
 		"captcha":  "验证码",
 	}
 }
 
+// 字母或数字
+func letterOrDigit(value interface{}, param string, datas map[string]interface{}, title string) error {
+	valueStr, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%s不能为空", title)
+	}
+	// 定义正则表达式模式
+	pattern := "^[a-zA-Z0-9]+$"
+	// 编译正则表达式
+	match, _ := regexp.MatchString(pattern, valueStr)
+	if !match {
+		return fmt.Errorf("%s只能为字母或数字", title)
+	}
+	return nil
+}
+
 func main() {
+	// 覆盖内置规则（RegisterRule、RegisterRules注册已存在的规则时返回validate.ErrRuleExists，不会覆盖）
+	if err := validate.ReplaceRule(validate.Rule{Name: "alphaNum", Fun: letterOrDigit}); err != nil {
+		panic(err)
+	}
 	// 注册自定义规则
-	validate.RegisterRules([]validate.Rule{
-		{Name: "alphaNum", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
-			valueStr, ok := value.(string)
-			if !ok {
-				return fmt.Errorf("%s不能为空", title)
-			}
-			// 定义正则表达式模式
-			pattern := "^[a-zA-Z0-9]+$"
-			// 编译正则表达式
-			match, _ := regexp.MatchString(pattern, valueStr)
-			if !match {
-				return fmt.Errorf("%s只能为字母或数字", title)
-			}
-			return nil
-		}},
-	})
+	if err := validate.RegisterRules([]validate.Rule{{Name: "letterOrDigit", Fun: letterOrDigit}}); err != nil {
+		panic(err)
+	}
+	if err := validate.RegisterRule(validate.Rule{Name: "required", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+		return nil
+	}}); errors.Is(err, validate.ErrRuleExists) {
+		fmt.Printf("注册规则失败！%v\r\n", err)
+	}
 	userLogin := &UserLogin{}
 	userLogin.InitValidator(userLogin)
 	userLogin.SetDatas(map[string]interface{}{
 		"username": "管理员",
 		"password": "123456",
+		"captcha":  "1234",
 	})
 	err := userLogin.Check()
 	if err != nil {
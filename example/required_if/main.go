@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserRegister struct {
+	validate.Validator
+	Type        string `json:"type" validate:"required|in:person,company" title:"用户类型"`
+	CompanyName string `json:"company_name" validate:"requiredIf:type,company|max:50" title:"公司名称"`
+	IdCard      string `json:"id_card" validate:"requiredUnless:type,company" title:"身份证号"`
+	Email       string `json:"email" validate:"requiredWithout:mobile|email" title:"邮箱"`
+	Mobile      string `json:"mobile" validate:"requiredWithout:email|mobile" title:"手机号"`
+	StartTime   string `json:"start_time" validate:"requiredWith:end_time|datetime" title:"开始时间"`
+	EndTime     string `json:"end_time" validate:"requiredWith:start_time|datetime" title:"结束时间"`
+}
+
+func main() {
+	user := &UserRegister{Type: "company", EndTime: "2024-01-01 00:00:00"}
+	user.InitValidator(user)
+	err := user.Check(validate.WithBatch(true))
+	if errs, ok := err.(*validate.Errors); ok {
+		for _, field := range errs.Fields() {
+			fmt.Printf("%s：%v\r\n", field, errs.Get(field))
+		}
+	} else if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type Product struct {
+	validate.Validator
+	Code  string `json:"code" validate:"required|regex:'^[A-Z]{2}-\\d{3}$'" title:"商品编码"`
+	Unit  string `json:"unit" validate:"required|in:'箱|盒',件" title:"单位"`
+	Spec  string `json:"spec" validate:"in:\"10,20\",\"30,40\"" title:"规格"`
+	Phone string `json:"phone" validate:"regex:^\\d{3}-\\d{8}\\|\\d{4}-\\d{7}$" title:"电话"`
+}
+
+func main() {
+	product := &Product{Code: "AB-12", Unit: "箱|盒", Spec: "10,20", Phone: "0755-1234567"}
+	product.InitValidator(product)
+	err := product.Check(validate.WithBatch(true))
+	fmt.Printf("商品验证：%v\r\n", err)
+
+	// 验证单个数据
+	err = validate.CheckVar("c", `in:"a,b",c`, "类型", nil)
+	fmt.Printf("类型验证：%v\r\n", err)
+
+	// 规则字符串语法错误（系统错误）
+	err = validate.CheckVar("a", `required|in:"a,b`, "类型", nil)
+	var syntaxErr *validate.RuleSyntaxError
+	if errors.As(err, &syntaxErr) {
+		fmt.Printf("规则语法错误：%v，位置：%d\r\n", syntaxErr.Message, syntaxErr.Offset)
+	}
+
+	// 自定义规则解析参数
+	params, _ := validate.ParseParams(`"a,b",c,d\,e`)
+	fmt.Printf("参数：%q\r\n", params)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserLogin struct {
+	validate.Validator
+	Username string `json:"username" validate:"required|alphaNum|max:20" title:"用户名"`
+	Password string `json:"password" validate:"required|length:6,12" title:"密码"`
+	Captcha  string `json:"captcha" validate:"required|length:4" title:"验证码"`
+}
+
+func (u *UserLogin) DefineScenes() map[string][]string {
+	return map[string][]string{
+		"login":    {"username", "password", "captcha"},
+		"register": {"username", "password"},
+	}
+}
+
+// 验证器定义（可在多个goroutine中共用）
+var userLoginSchema = validate.MustNewSchema(&UserLogin{})
+
+func main() {
+	requests := []map[string]interface{}{
+		{"username": "admin", "password": "123456", "captcha": "1234"},
+		{"username": "admin", "password": "123"},
+		{"username": "管理员", "password": "123456"},
+		{"password": "123456", "captcha": "12"},
+	}
+	results := make([]*validate.Result, len(requests))
+	var wg sync.WaitGroup
+	for i, datas := range requests {
+		wg.Add(1)
+		go func(i int, datas map[string]interface{}) {
+			defer wg.Done()
+			results[i] = userLoginSchema.Validate("login", datas, validate.WithBatch(true))
+		}(i, datas)
+	}
+	wg.Wait()
+	for i, result := range results {
+		if !result.Valid {
+			fmt.Printf("请求%d验证失败！%v\r\n", i+1, result.Err)
+			for _, fieldErr := range result.Errors {
+				fmt.Printf("  字段：%s，规则：%s，提示：%s\r\n", fieldErr.Field, fieldErr.Rule, fieldErr.Message)
+			}
+			continue
+		}
+		// 验证器实例可获取结构体属性
+		user := result.Validator.(*UserLogin)
+		fmt.Printf("请求%d验证通过，用户名：%s，验证数据：%v\r\n", i+1, user.Username, result.Datas)
+	}
+
+	// 验证器实例返回验证结果
+	user := &UserLogin{Username: "admin", Password: "123456"}
+	user.InitValidator(user)
+	result := user.Validate("register")
+	fmt.Printf("注册验证：%v，场景：%s，验证数据：%v，耗时：%v\r\n", result.Valid, result.Scene, result.Datas, result.Duration)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserLogin struct {
+	validate.Validator
+	Username string `json:"username" validate:"required|alphaNum|max:20" title:"用户名" msg:"required:请输入用户名|max:用户名最多20个字符"`
+	Password string `json:"password" validate:"required|length:6,12" title:"密码"`
+	Captcha  string `json:"captcha" validate:"required" title:"验证码"`
+}
+
+// 覆盖标签定义的验证规则
+func (u *UserLogin) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"captcha": "required|length:4",
+	}
+}
+
+func main() {
+	userLogin := &UserLogin{Password: "123456", Captcha: "123"}
+	userLogin.InitValidator(userLogin)
+	err := userLogin.Check(validate.WithBatch(true))
+	if err != nil {
+		fmt.Printf("登录验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("登录验证通过")
+	}
+}
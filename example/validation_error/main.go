@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/worklz/go-validate"
+)
+
+type UserLogin struct {
+	validate.Validator
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (u *UserLogin) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		"username": "required|alphaNum|max:20",
+		"password": "required|length:6,12",
+	}
+}
+
+func (u *UserLogin) DefineTitles() map[string]string {
+	return map[string]string{
+		"username": "用户名",
+		"password": "密码",
+	}
+}
+
+type UserInfo struct {
+	validate.Validator
+	Nickname string `json:"nickname"`
+}
+
+func (u *UserInfo) DefineRules() map[string]interface{} {
+	return map[string]interface{}{
+		// 验证规则参数配置错误，验证时返回系统错误
+		"nickname": "required|max:abc",
+	}
+}
+
+func main() {
+	userLogin := &UserLogin{Username: "admin", Password: "123"}
+	userLogin.InitValidator(userLogin)
+	err := userLogin.Check()
+	// 获取验证失败的字段、规则等信息
+	var validationErr *validate.ValidationError
+	if errors.As(err, &validationErr) {
+		fmt.Printf("字段：%s 标题：%s 规则：%s 参数：%s 值：%v 错误信息：%s\r\n",
+			validationErr.Field, validationErr.Title, validationErr.Rule, validationErr.Param, validationErr.Value, validationErr.Message)
+	} else if err != nil {
+		fmt.Printf("验证失败！%v\r\n", err)
+	} else {
+		fmt.Println("验证通过")
+	}
+
+	// 验证单个数据
+	err = validate.CheckVar("abc", "number", "年龄", nil)
+	if errors.As(err, &validationErr) {
+		fmt.Printf("标题：%s 规则：%s 值：%v 错误信息：%s\r\n",
+			validationErr.Title, validationErr.Rule, validationErr.Value, validationErr.Message)
+	}
+
+	// 区分系统错误（验证器定义、规则配置错误）与验证失败错误
+	userInfo := &UserInfo{Nickname: "admin"}
+	userInfo.InitValidator(userInfo)
+	err = userInfo.Check()
+	switch {
+	case errors.Is(err, validate.ErrSystem):
+		fmt.Printf("系统错误（500）！%v\r\n", err)
+	case errors.Is(err, validate.ErrValidation):
+		fmt.Printf("验证失败（422）！%v\r\n", err)
+	default:
+		fmt.Println("验证通过")
+	}
+}
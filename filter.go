@@ -0,0 +1,269 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 过滤器已存在（可通过errors.Is判断）
+var ErrFilterExists = errors.New("filter already exists")
+
+// 过滤器函数，返回过滤后的值，返回错误时该字段验证失败（如：toInt转换失败）
+type FilterFunc func(value interface{}) (interface{}, error)
+
+var (
+	filtersMu sync.RWMutex
+	filters   = map[string]FilterFunc{
+		"trim":      stringFilter(strings.TrimSpace),
+		"ltrim":     stringFilter(func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) }),
+		"rtrim":     stringFilter(func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) }),
+		"lower":     stringFilter(strings.ToLower),
+		"upper":     stringFilter(strings.ToUpper),
+		"stripTags": stringFilter(func(s string) string { return htmlTagRegexp.ReplaceAllString(s, "") }),
+		"toInt":     typeFilter(reflect.TypeOf(int(0))),
+		"toFloat":   typeFilter(reflect.TypeOf(float64(0))),
+		"toBool":    toBoolFilter,
+		"toString":  toStringFilter,
+	}
+)
+
+// html标签正则
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// 注册过滤器，过滤器已存在时返回ErrFilterExists
+func RegisterFilter(name string, fun FilterFunc) (err error) {
+	if name == "" {
+		err = errors.New("filter name is empty")
+		return
+	}
+	if fun == nil {
+		err = fmt.Errorf("filter %s fun is empty", name)
+		return
+	}
+	filtersMu.Lock()
+	defer filtersMu.Unlock()
+	if _, ok := filters[name]; ok {
+		err = fmt.Errorf("%w: %s", ErrFilterExists, name)
+		return
+	}
+	filters[name] = fun
+	return
+}
+
+// 获取过滤器
+func LookupFilter(name string) (fun FilterFunc, ok bool) {
+	filtersMu.RLock()
+	defer filtersMu.RUnlock()
+	fun, ok = filters[name]
+	return
+}
+
+// 字符串过滤器，过滤字符串及字符串切片（[]string、[]interface{}中的字符串），其他类型的值不变
+func stringFilter(fn func(s string) string) FilterFunc {
+	return func(value interface{}) (interface{}, error) {
+		switch val := value.(type) {
+		case string:
+			return fn(val), nil
+		case []string:
+			items := make([]string, len(val))
+			for i, item := range val {
+				items[i] = fn(item)
+			}
+			return items, nil
+		case []interface{}:
+			items := make([]interface{}, len(val))
+			for i, item := range val {
+				if s, ok := item.(string); ok {
+					items[i] = fn(s)
+				} else {
+					items[i] = item
+				}
+			}
+			return items, nil
+		}
+		// 自定义的字符串类型
+		if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+			return reflect.ValueOf(fn(v.String())).Convert(v.Type()).Interface(), nil
+		}
+		return value, nil
+	}
+}
+
+// 类型转换过滤器，空值（nil、空字符串）不变，字符串去除首尾空白后转换
+func typeFilter(t reflect.Type) FilterFunc {
+	return func(value interface{}) (interface{}, error) {
+		if s, ok := value.(string); ok {
+			value = strings.TrimSpace(s)
+		}
+		if value == nil || value == "" {
+			return value, nil
+		}
+		result, err := coerceValue(value, t)
+		if err != nil {
+			return nil, err
+		}
+		return result.Interface(), nil
+	}
+}
+
+// 转换为布尔值，字符串按strconv.ParseBool转换，数字非0为true，空值（nil、空字符串）不变
+func toBoolFilter(value interface{}) (interface{}, error) {
+	v := indirectValue(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0, nil
+	}
+	return typeFilter(reflect.TypeOf(false))(value)
+}
+
+// 转换为字符串，nil不变
+func toStringFilter(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch val := value.(type) {
+	case string:
+		return val, nil
+	case []byte:
+		return string(val), nil
+	case fmt.Stringer:
+		return val.String(), nil
+	}
+	v := indirectValue(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return nil, fmt.Errorf("不支持将%v转换为字符串", v.Type())
+}
+
+// 判断是否为过滤器（与验证规则同名时作为验证规则）
+func (v *Validator) isFilter(name string) bool {
+	if _, ok := v.rules.Lookup(name); ok {
+		return false
+	}
+	_, ok := LookupFilter(name)
+	return ok
+}
+
+// 获取字段的过滤器
+// 依次为DefineFilters定义的过滤器、验证规则字符串中的过滤器
+func (v *Validator) fieldFilters(filterStr string, dataRules interface{}) (names []string, err error) {
+	if filterStr != "" {
+		var ruleItems []ruleItem
+		ruleItems, err = v.plan.ruleItems(filterStr)
+		if err != nil {
+			err = v.SetSystemError(err)
+			return
+		}
+		for _, ruleItem := range ruleItems {
+			if _, ok := LookupFilter(ruleItem.Name); !ok {
+				err = v.SetSystemError(fmt.Sprintf("过滤器%s未定义", ruleItem.Name))
+				return
+			}
+			names = append(names, ruleItem.Name)
+		}
+	}
+	if dataRuleStr, isStr := dataRules.(string); isStr && dataRuleStr != "" {
+		var ruleItems []ruleItem
+		ruleItems, err = v.plan.ruleItems(dataRuleStr)
+		if err != nil {
+			err = v.SetSystemError(err)
+			return
+		}
+		for _, ruleItem := range ruleItems {
+			if v.isFilter(ruleItem.Name) {
+				names = append(names, ruleItem.Name)
+			}
+		}
+	}
+	return
+}
+
+// 验证前过滤数据，过滤后的值设置到验证数据并同步到对应的json标签属性上
+// 过滤失败的字段返回其验证错误（键为数据完整路径），验证时不再验证该字段的规则
+func (v *Validator) filterDatas(datas map[string]interface{}, checkRules map[string]interface{}, messages map[string]string, titles map[string]string) (filterErrs map[string]error, err error) {
+	filterDefines, err := v.GetFilters()
+	if err != nil {
+		return
+	}
+	// 当前验证字段，及DefineFilters定义的其他字段（按字段排序）
+	dataKeys := append([]string{}, v.CheckKeys...)
+	otherKeys := []string{}
+	for dataKey := range filterDefines {
+		if !inArray(dataKey, v.CheckKeys) {
+			otherKeys = append(otherKeys, dataKey)
+		}
+	}
+	sort.Strings(otherKeys)
+	dataKeys = append(dataKeys, otherKeys...)
+
+	filtered := false
+	for _, dataKey := range dataKeys {
+		var names []string
+		names, err = v.fieldFilters(filterDefines[dataKey], checkRules[dataKey])
+		if err != nil {
+			return
+		}
+		if len(names) == 0 {
+			continue
+		}
+		for _, data := range getPathValues(datas, dataKey) {
+			if !data.Exists {
+				continue
+			}
+			value := data.Value
+			var filterErr error
+			for _, name := range names {
+				fun, _ := LookupFilter(name)
+				value, filterErr = fun(value)
+				if filterErr == nil {
+					continue
+				}
+				message := fieldMessage(messages, name, data.Path, dataKey)
+				if message == "" {
+					message = translate(v.locale, "type")
+				}
+				if filterErrs == nil {
+					filterErrs = map[string]error{}
+				}
+				filterErrs[data.Path] = newFieldError(data.Path, fieldTitle(titles, data.Path, dataKey, v.locale), name, "", data.Value, filterErr, message, v.locale)
+				break
+			}
+			if filterErr != nil {
+				continue
+			}
+			// 过滤后的值无法设置时（如类型不能赋值给属性类型）保持原值
+			if !v.setDataValue(datas, data.Path, value) {
+				continue
+			}
+			filtered = true
+		}
+	}
+	// 过滤后的值同步到属性上
+	if filtered {
+		err = v.SetDatas(datas)
+	}
+	return
+}
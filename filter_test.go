@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+)
+
+type filterTestUser struct {
+	Validator
+	Name  string      `json:"name" validate:"required" title:"姓名"`
+	Age   string      `json:"age" title:"年龄"`
+	Level int         `json:"level" title:"等级"`
+	Extra interface{} `json:"extra" title:"附加信息"`
+}
+
+func (u *filterTestUser) DefineFilters() map[string]string {
+	return map[string]string{"age": "toInt", "level": "toString", "extra": "toInt"}
+}
+
+func TestFilterUnassignableValue(t *testing.T) {
+	for _, coerce := range []bool{false, true} {
+		user := &filterTestUser{}
+		user.InitValidator(user)
+		user.SetCoerce(coerce)
+		datas := map[string]interface{}{"name": "a", "age": " 18 ", "level": 3, "extra": "7"}
+		if err := user.SetDatas(datas); err != nil {
+			t.Fatalf("SetDatas返回错误：%v", err)
+		}
+		if err := user.Check(); err != nil {
+			t.Errorf("Coerce=%v：Check返回错误：%v（系统错误：%v）", coerce, err, errors.Is(err, ErrSystem))
+			continue
+		}
+		// 过滤后的int不能赋值给string属性时保持原值
+		if user.Age != " 18 " || datas["age"] != " 18 " {
+			t.Errorf("Coerce=%v：Age = %q，数据 = %#v，期望保持原值", coerce, user.Age, datas["age"])
+		}
+		wantLevel := interface{}(3)
+		if coerce {
+			// 开启类型转换时"3"可转换为int
+			wantLevel = "3"
+		}
+		if user.Level != 3 || datas["level"] != wantLevel {
+			t.Errorf("Coerce=%v：Level = %d，数据 = %#v，期望 %#v", coerce, user.Level, datas["level"], wantLevel)
+		}
+		// interface{}属性可设置任意类型
+		if user.Extra != 7 || datas["extra"] != 7 {
+			t.Errorf("Coerce=%v：Extra = %#v，数据 = %#v，期望 7", coerce, user.Extra, datas["extra"])
+		}
+	}
+}
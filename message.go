@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 提示信息占位符，如：{title}、{param.0}
+var messagePlaceholderRegex = regexp.MustCompile(`\{([a-zA-Z_]+(?:\.\d+)?)\}`)
+
+// 渲染模板，lookup返回占位符对应的值，未找到的占位符保持不变
+func renderTemplate(message string, lookup func(name string) (string, bool)) string {
+	if !strings.Contains(message, "{") {
+		return message
+	}
+	return messagePlaceholderRegex.ReplaceAllStringFunc(message, func(placeholder string) string {
+		if value, ok := lookup(placeholder[1 : len(placeholder)-1]); ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// 渲染提示信息模板
+// 支持的占位符：
+// {title} 字段标题
+// {field} 字段（完整路径，如：items.0.sku）
+// {rule} 验证规则名称
+// {param} 验证规则参数
+// {param.0} 验证规则参数中“,”间隔的第1个参数（已去除引号及转义），依此类推
+// {value} 验证的值
+// 以及验证规则错误（*RuleError）中的占位符变量
+func renderMessage(message string, ve *ValidationError) string {
+	var ruleErr *RuleError
+	errors.As(ve.Err, &ruleErr)
+	return renderTemplate(message, func(name string) (string, bool) {
+		if ruleErr != nil {
+			if value, ok := ruleErr.Vars[name]; ok {
+				return fmt.Sprintf("%v", value), true
+			}
+		}
+		switch name {
+		case "title":
+			return ve.Title, true
+		case "field":
+			return ve.Field, true
+		case "rule":
+			return ve.Rule, true
+		case "value":
+			return fmt.Sprintf("%v", ve.Value), true
+		case "param":
+			return ve.Param, true
+		}
+		if strings.HasPrefix(name, "param.") {
+			index, _ := strconv.Atoi(name[len("param."):])
+			params, err := ParseParams(ve.Param)
+			if err != nil {
+				params = strings.Split(ve.Param, ",")
+			}
+			if index < len(params) {
+				return params[index], true
+			}
+			return "", true
+		}
+		return "", false
+	})
+}
+
+// 创建字段验证失败错误
+// message 自定义提示信息，为空时使用验证规则返回的错误信息（根据语言翻译），提示信息均支持占位符
+// locale 语言
+func newFieldError(field string, title string, rule string, param string, value interface{}, err error, message string, locale string) *ValidationError {
+	ve := newValidationError(field, title, rule, param, value, err)
+	if message != "" {
+		ve.Message = message
+	} else if translated := translateRuleError(err, locale); translated != "" {
+		ve.Message = translated
+	}
+	ve.Message = renderMessage(ve.Message, ve)
+	return ve
+}
+
+// 翻译验证规则错误（*RuleError），根据其提示信息键翻译
+func translateRuleError(err error, locale string) string {
+	var ruleErr *RuleError
+	if errors.As(err, &ruleErr) {
+		return translate(locale, ruleErr.Key)
+	}
+	return ""
+}
+
+// 获取验证规则定义的各语言提示信息
+// message 自定义提示信息，不为空时直接返回；规则返回*RuleError时使用其提示信息键翻译，返回空
+func ruleMessage(message string, rule Rule, err error, locale string) string {
+	if message != "" || len(rule.Messages) == 0 {
+		return message
+	}
+	var ruleErr *RuleError
+	if errors.As(err, &ruleErr) {
+		return ""
+	}
+	message, _ = pickLocaleMessage(rule.Messages, locale)
+	return message
+}
@@ -0,0 +1,92 @@
+package validate
+
+// 验证选项（作用于单次验证调用）
+type CheckOption func(o *checkOptions)
+
+// 验证选项值
+type checkOptions struct {
+	batch      *bool       // 是否批量验证
+	locale     string      // 提示信息语言
+	registries []*Registry // 验证规则注册表（后设置的优先查找）
+	coerce     *bool       // 是否转换数据类型
+	coerced    bool        // 是否已在设置验证数据时处理类型转换选项
+	err        error       // 选项错误
+}
+
+// 解析验证选项
+func newCheckOptions(opts []CheckOption) *checkOptions {
+	o := &checkOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// 设置本次验证是否批量验证
+// 批量验证会验证所有字段，失败时返回包含所有字段错误的*Errors
+func WithBatch(batch bool) CheckOption {
+	return func(o *checkOptions) {
+		o.batch = &batch
+	}
+}
+
+// 设置本次验证提示信息的语言，如：zh-CN、en
+func WithLocale(locale string) CheckOption {
+	return func(o *checkOptions) {
+		o.locale = locale
+	}
+}
+
+// 设置是否转换数据类型（验证数据同步到属性时转换为属性类型）
+// 仅可用于设置验证数据的调用：Schema.Validate、Schema.ValidateContext、Bind
+// 验证器实例的Check、CheckScene等使用时返回系统错误（数据已在SetDatas时同步到属性上），请在SetDatas前调用SetCoerce
+func WithCoerce(coerce bool) CheckOption {
+	return func(o *checkOptions) {
+		o.coerce = &coerce
+	}
+}
+
+// 设置本次验证使用的验证规则注册表，优先于验证器的局部规则、默认注册表中的规则查找
+func WithRegistry(registry *Registry) CheckOption {
+	return func(o *checkOptions) {
+		if registry != nil {
+			o.registries = append([]*Registry{registry}, o.registries...)
+		}
+	}
+}
+
+// 设置本次验证使用的验证规则，优先于验证器的局部规则、默认注册表中的规则查找
+func WithRules(rules ...Rule) CheckOption {
+	return func(o *checkOptions) {
+		registry := NewRegistry()
+		for _, rule := range rules {
+			if err := registry.Replace(rule); err != nil && o.err == nil {
+				o.err = err
+			}
+		}
+		o.registries = append([]*Registry{registry}, o.registries...)
+	}
+}
+
+// 设置本次验证的多个验证规则注册表（按查找顺序，用于子验证器继承）
+func withRegistries(registries []*Registry) CheckOption {
+	return func(o *checkOptions) {
+		o.registries = append(append([]*Registry{}, registries...), o.registries...)
+	}
+}
+
+// 根据验证选项设置验证器是否转换数据类型，返回标记类型转换选项已处理的验证选项
+func applyCoerceOption(v ValidatorInterface, opts []CheckOption) []CheckOption {
+	checkOpts := newCheckOptions(opts)
+	if checkOpts.coerce == nil {
+		return opts
+	}
+	if c, ok := v.(interface{ SetCoerce(coerce bool) }); ok {
+		c.SetCoerce(*checkOpts.coerce)
+	}
+	return append(append([]CheckOption{}, opts...), func(o *checkOptions) {
+		o.coerced = true
+	})
+}
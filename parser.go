@@ -0,0 +1,164 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 验证规则字符串语法
+// 规则之间使用“|”间隔，规则名称与参数之间使用第一个“:”间隔，多个参数之间使用“,”间隔，如：required|length:6,12
+// 参数值以引号（"或'）开头时，引号内的“|”、“,”不作为间隔符，如：in:"a,b",c、regex:'^\d{3}|\d{4}$'
+// 未加引号的参数值中可使用“\|”、“\,”转义间隔符，引号内可使用“\"”、“\'”转义引号，“\\”表示“\”，其他“\”保持不变（如正则中的\d）
+
+// 验证规则字符串语法错误
+type RuleSyntaxError struct {
+	Rule    string // 验证规则字符串
+	Offset  int    // 错误位置（字节偏移，从0开始）
+	Message string // 错误信息
+}
+
+// 错误信息
+func (e *RuleSyntaxError) Error() string {
+	return fmt.Sprintf("验证规则“%s”语法错误（位置%d）：%s", e.Rule, e.Offset, e.Message)
+}
+
+// 语法错误为系统错误
+func (e *RuleSyntaxError) Is(target error) bool {
+	return target == ErrSystem
+}
+
+// 解析后的验证规则
+type ruleItem struct {
+	Name   string // 规则名称
+	Param  string // 规则参数（原始字符串，保留引号及转义，可通过ParseParams、ParseParam解析）
+	Offset int    // 规则在规则字符串中的位置
+}
+
+// 解析验证规则字符串
+func parseRuleString(rule string) (items []ruleItem, err error) {
+	pos := 0
+	for pos < len(rule) {
+		// 规则名称
+		nameEnd := pos
+		for nameEnd < len(rule) && rule[nameEnd] != ':' && rule[nameEnd] != '|' {
+			nameEnd++
+		}
+		item := ruleItem{Name: rule[pos:nameEnd], Offset: pos}
+		end := nameEnd
+		// 规则参数
+		if nameEnd < len(rule) && rule[nameEnd] == ':' {
+			end = nameEnd + 1
+			for {
+				end, err = scanParamValue(rule, end, ",|")
+				if err != nil {
+					return nil, err
+				}
+				if end >= len(rule) || rule[end] == '|' {
+					break
+				}
+				end++
+			}
+			item.Param = rule[nameEnd+1 : end]
+		}
+		if item.Name == "" {
+			// 忽略空规则，如：required||email
+			if end > pos {
+				return nil, &RuleSyntaxError{Rule: rule, Offset: pos, Message: "规则名称不能为空"}
+			}
+		} else {
+			items = append(items, item)
+		}
+		pos = end + 1
+	}
+	return
+}
+
+// 扫描参数值，返回参数值结束位置（间隔符位置或字符串末尾）
+// seps 间隔符
+func scanParamValue(s string, start int, seps string) (end int, err error) {
+	if start < len(s) && (s[start] == '"' || s[start] == '\'') {
+		quote := s[start]
+		end = start + 1
+		for ; end < len(s); end++ {
+			if s[end] == '\\' && end+1 < len(s) && (s[end+1] == quote || s[end+1] == '\\') {
+				end++
+				continue
+			}
+			if s[end] == quote {
+				break
+			}
+		}
+		if end >= len(s) {
+			return end, &RuleSyntaxError{Rule: s, Offset: start, Message: "引号未闭合"}
+		}
+		end++
+		if end < len(s) && !strings.ContainsRune(seps, rune(s[end])) {
+			return end, &RuleSyntaxError{Rule: s, Offset: end, Message: "引号后需为间隔符"}
+		}
+		return
+	}
+	for end = start; end < len(s); end++ {
+		if s[end] == '\\' && end+1 < len(s) && (s[end+1] == '\\' || strings.ContainsRune(seps, rune(s[end+1]))) {
+			end++
+			continue
+		}
+		if strings.ContainsRune(seps, rune(s[end])) {
+			return
+		}
+	}
+	return
+}
+
+// 去除参数值的引号及转义
+func unquoteParamValue(value string, seps string) string {
+	if !strings.Contains(value, "\\") && (len(value) < 2 || (value[0] != '"' && value[0] != '\'')) {
+		return value
+	}
+	escapable := seps + "\\"
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		escapable = string(value[0]) + "\\"
+		value = value[1 : len(value)-1]
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) && strings.ContainsRune(escapable, rune(value[i+1])) {
+			i++
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// 解析“,”间隔的验证规则参数，支持引号及转义，如：in:"a,b",c的参数解析为：a,b和c
+// 参数为空时返回nil
+func ParseParams(param string) (params []string, err error) {
+	if param == "" {
+		return
+	}
+	pos := 0
+	for {
+		end, err := scanParamValue(param, pos, ",|")
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, unquoteParamValue(param[pos:end], ",|"))
+		if end >= len(param) {
+			return params, nil
+		}
+		pos = end + 1
+	}
+}
+
+// 解析单个验证规则参数（参数中的“,”不作为间隔符），支持引号及转义，如：regex:'^\d{3}|\d{4}$'的参数解析为：^\d{3}|\d{4}$
+func ParseParam(param string) (value string, err error) {
+	end, err := scanParamValue(param, 0, "|")
+	if err != nil {
+		return
+	}
+	if end < len(param) {
+		err = &RuleSyntaxError{Rule: param, Offset: end, Message: "参数中的“|”需转义或使用引号"}
+		return
+	}
+	value = unquoteParamValue(param, "|,")
+	return
+}
@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRuleString(t *testing.T) {
+	tests := []struct {
+		rule  string
+		items []ruleItem
+	}{
+		{"", nil},
+		{"required", []ruleItem{{Name: "required"}}},
+		{"required|length:6,12", []ruleItem{{Name: "required"}, {Name: "length", Param: "6,12", Offset: 9}}},
+		// 忽略空规则
+		{"required||email|", []ruleItem{{Name: "required"}, {Name: "email", Offset: 10}}},
+		// 空参数
+		{"length:", []ruleItem{{Name: "length"}}},
+		{"length:|required", []ruleItem{{Name: "length"}, {Name: "required", Offset: 8}}},
+		{"in:,a", []ruleItem{{Name: "in", Param: ",a"}}},
+		// 引号内的间隔符
+		{`in:"a,b",c`, []ruleItem{{Name: "in", Param: `"a,b",c`}}},
+		{`regex:'^\d{3}|\d{4}$'|required`, []ruleItem{{Name: "regex", Param: `'^\d{3}|\d{4}$'`}, {Name: "required", Offset: 22}}},
+		{`in:"a\"|b"|required`, []ruleItem{{Name: "in", Param: `"a\"|b"`}, {Name: "required", Offset: 11}}},
+		// 转义的间隔符
+		{`in:a\|b,c|required`, []ruleItem{{Name: "in", Param: `a\|b,c`}, {Name: "required", Offset: 10}}},
+		{`in:a\,b`, []ruleItem{{Name: "in", Param: `a\,b`}}},
+		{`in:a\\|required`, []ruleItem{{Name: "in", Param: `a\\`}, {Name: "required", Offset: 7}}},
+		// 参数中的“:”不作为间隔符
+		{`regex:^a:b$`, []ruleItem{{Name: "regex", Param: `^a:b$`}}},
+		{`regex:^a\:b$`, []ruleItem{{Name: "regex", Param: `^a\:b$`}}},
+	}
+	for _, test := range tests {
+		items, err := parseRuleString(test.rule)
+		if err != nil {
+			t.Errorf("parseRuleString(%q)返回错误：%v", test.rule, err)
+			continue
+		}
+		if !reflect.DeepEqual(items, test.items) {
+			t.Errorf("parseRuleString(%q) = %+v，期望 %+v", test.rule, items, test.items)
+		}
+	}
+}
+
+func TestParseRuleStringSyntaxError(t *testing.T) {
+	tests := []struct {
+		rule    string
+		offset  int
+		message string
+	}{
+		{`in:"a,b`, 3, "引号未闭合"},
+		{`required|in:'a`, 12, "引号未闭合"},
+		{`in:"a\"b`, 3, "引号未闭合"},
+		{`in:"a",'b|c`, 7, "引号未闭合"},
+		{`in:"a"b`, 6, "引号后需为间隔符"},
+		{`in:"a",'b'c|required`, 10, "引号后需为间隔符"},
+		{`:6,12`, 0, "规则名称不能为空"},
+		{`required|:a`, 9, "规则名称不能为空"},
+	}
+	for _, test := range tests {
+		_, err := parseRuleString(test.rule)
+		checkRuleSyntaxError(t, "parseRuleString", test.rule, err, test.offset, test.message)
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	tests := []struct {
+		param  string
+		params []string
+	}{
+		{"", nil},
+		{"6,12", []string{"6", "12"}},
+		// 空参数
+		{",", []string{"", ""}},
+		{"a,,b", []string{"a", "", "b"}},
+		{`"",b`, []string{"", "b"}},
+		// 引号
+		{`"a,b",c`, []string{"a,b", "c"}},
+		{`'a|b',"c"`, []string{"a|b", "c"}},
+		{`"a\"b",'c\'d'`, []string{`a"b`, `c'd`}},
+		{`"a\\"`, []string{`a\`}},
+		// 转义
+		{`a\,b,c`, []string{"a,b", "c"}},
+		{`a\|b`, []string{"a|b"}},
+		{`a\\,b`, []string{`a\`, "b"}},
+		{`a\:b`, []string{`a\:b`}},
+		{`\d+,\w`, []string{`\d+`, `\w`}},
+	}
+	for _, test := range tests {
+		params, err := ParseParams(test.param)
+		if err != nil {
+			t.Errorf("ParseParams(%q)返回错误：%v", test.param, err)
+			continue
+		}
+		if !reflect.DeepEqual(params, test.params) {
+			t.Errorf("ParseParams(%q) = %q，期望 %q", test.param, params, test.params)
+		}
+	}
+
+	errTests := []struct {
+		param   string
+		offset  int
+		message string
+	}{
+		{`"a,b`, 0, "引号未闭合"},
+		{`a,'b`, 2, "引号未闭合"},
+		{`"a"b`, 3, "引号后需为间隔符"},
+	}
+	for _, test := range errTests {
+		_, err := ParseParams(test.param)
+		checkRuleSyntaxError(t, "ParseParams", test.param, err, test.offset, test.message)
+	}
+}
+
+func TestParseParam(t *testing.T) {
+	tests := []struct {
+		param string
+		value string
+	}{
+		{"", ""},
+		{`""`, ""},
+		{"a,b", "a,b"},
+		{`'^\d{3}|\d{4}$'`, `^\d{3}|\d{4}$`},
+		{`"a,b|c"`, "a,b|c"},
+		{`a\|b`, "a|b"},
+		{`a\,b`, "a,b"},
+		{`a\:b`, `a\:b`},
+		{`^\d+$`, `^\d+$`},
+	}
+	for _, test := range tests {
+		value, err := ParseParam(test.param)
+		if err != nil {
+			t.Errorf("ParseParam(%q)返回错误：%v", test.param, err)
+			continue
+		}
+		if value != test.value {
+			t.Errorf("ParseParam(%q) = %q，期望 %q", test.param, value, test.value)
+		}
+	}
+
+	errTests := []struct {
+		param   string
+		offset  int
+		message string
+	}{
+		{`^\d{3}|\d{4}$`, 6, "参数中的“|”需转义或使用引号"},
+		{`'abc`, 0, "引号未闭合"},
+		{`'a'b`, 3, "引号后需为间隔符"},
+	}
+	for _, test := range errTests {
+		_, err := ParseParam(test.param)
+		checkRuleSyntaxError(t, "ParseParam", test.param, err, test.offset, test.message)
+	}
+}
+
+// 检查语法错误的位置及信息
+func checkRuleSyntaxError(t *testing.T, funcName string, input string, err error, offset int, message string) {
+	t.Helper()
+	var syntaxErr *RuleSyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("%s(%q)返回错误 %v，期望 *RuleSyntaxError", funcName, input, err)
+		return
+	}
+	if syntaxErr.Offset != offset || syntaxErr.Message != message {
+		t.Errorf("%s(%q)错误位置 = %d（%s），期望 %d（%s）", funcName, input, syntaxErr.Offset, syntaxErr.Message, offset, message)
+	}
+	if syntaxErr.Rule != input {
+		t.Errorf("%s(%q)错误的规则字符串 = %q", funcName, input, syntaxErr.Rule)
+	}
+	if !errors.Is(err, ErrSystem) {
+		t.Errorf("%s(%q)错误应为系统错误", funcName, input)
+	}
+}
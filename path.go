@@ -0,0 +1,319 @@
+package validate
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 路径数据
+type pathValue struct {
+	Path   string      // 数据完整路径，如：items.0.sku
+	Value  interface{} // 数据值
+	Exists bool        // 数据是否存在
+}
+
+// 判断是否为多级路径
+func isNestedPath(path string) bool {
+	return strings.Contains(path, ".")
+}
+
+// 根据路径获取数据，支持“.”间隔的多级路径（结构体、map、切片），如：address.city、items.0.sku
+func getValueByPath(datas map[string]interface{}, path string) (value interface{}, exists bool) {
+	if value, exists = datas[path]; exists || !isNestedPath(path) {
+		return
+	}
+	values := getPathValues(datas, path)
+	if len(values) != 1 {
+		return nil, false
+	}
+	return values[0].Value, values[0].Exists
+}
+
+// 根据完整路径设置数据，如：items.0.sku
+// 下级数据支持map、切片、数组、结构体（根据json标签或属性名称）及其指针，修改时复制上级数据（不修改原数据中的map、切片、结构体），
+// 上级数据不存在、值的类型不能赋值给下级数据的类型时返回false
+func setValueByPath(datas map[string]interface{}, path string, value interface{}) bool {
+	if _, exists := datas[path]; exists || !isNestedPath(path) {
+		datas[path] = value
+		return true
+	}
+	segments := strings.Split(path, ".")
+	child, exists := datas[segments[0]]
+	if !exists || child == nil {
+		return false
+	}
+	result, ok := setChildValue(reflect.ValueOf(child), segments[1:], value)
+	if !ok {
+		return false
+	}
+	datas[segments[0]] = result.Interface()
+	return true
+}
+
+// 设置下级数据，返回复制并设置后的数据
+func setChildValue(v reflect.Value, segments []string, value interface{}) (result reflect.Value, ok bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		var elem reflect.Value
+		if elem, ok = setChildValue(v.Elem(), segments, value); !ok {
+			return
+		}
+		result = reflect.New(v.Type().Elem())
+		result.Elem().Set(elem)
+		return result, true
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		return setChildValue(v.Elem(), segments, value)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		key := reflect.ValueOf(segments[0]).Convert(v.Type().Key())
+		var child reflect.Value
+		if child, ok = newChildValue(v.MapIndex(key), v.Type().Elem(), segments[1:], value); !ok {
+			return
+		}
+		result = reflect.MakeMapWithSize(v.Type(), v.Len()+1)
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), iter.Value())
+		}
+		result.SetMapIndex(key, child)
+		return result, true
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= v.Len() {
+			return
+		}
+		var child reflect.Value
+		if child, ok = newChildValue(v.Index(index), v.Type().Elem(), segments[1:], value); !ok {
+			return
+		}
+		if v.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(result, v)
+		} else {
+			result = reflect.New(v.Type()).Elem()
+			result.Set(v)
+		}
+		result.Index(index).Set(child)
+		return result, true
+	case reflect.Struct:
+		fieldIndex := structFieldIndex(v.Type(), segments[0])
+		if fieldIndex == -1 {
+			return
+		}
+		var child reflect.Value
+		if child, ok = newChildValue(v.Field(fieldIndex), v.Type().Field(fieldIndex).Type, segments[1:], value); !ok {
+			return
+		}
+		result = reflect.New(v.Type()).Elem()
+		result.Set(v)
+		result.Field(fieldIndex).Set(child)
+		return result, true
+	}
+	return
+}
+
+// 获取设置后的下级数据，需可赋值给下级数据的类型
+// child 原下级数据（最后一级可不存在），t 下级数据的类型，segments 剩余路径
+func newChildValue(child reflect.Value, t reflect.Type, segments []string, value interface{}) (result reflect.Value, ok bool) {
+	if len(segments) == 0 {
+		result = reflect.ValueOf(value)
+		if !result.IsValid() {
+			return reflect.Zero(t), true
+		}
+	} else {
+		if !child.IsValid() {
+			return
+		}
+		if result, ok = setChildValue(child, segments, value); !ok {
+			return
+		}
+	}
+	if !result.Type().AssignableTo(t) {
+		return reflect.Value{}, false
+	}
+	return result, true
+}
+
+// 根据路径获取数据，路径中的“*”表示切片的所有元素（map的所有值），如：items.*.sku、tags.*
+// 每个匹配的数据返回其完整路径，如：items.0.sku、items.1.sku、tags.3
+func getPathValues(datas map[string]interface{}, path string) []pathValue {
+	if value, exists := datas[path]; exists || !isNestedPath(path) {
+		return []pathValue{{Path: path, Value: value, Exists: exists}}
+	}
+	segments := strings.Split(path, ".")
+	value, exists := datas[segments[0]]
+	values := []pathValue{{Path: segments[0], Value: value, Exists: exists}}
+	for _, segment := range segments[1:] {
+		next := make([]pathValue, 0, len(values))
+		for _, pv := range values {
+			// 上级数据不存在，下级数据也不存在（通配符不匹配任何数据，上级数据是否必填由其自身的规则验证）
+			if !pv.Exists {
+				if segment != "*" {
+					next = append(next, pathValue{Path: pv.Path + "." + segment})
+				}
+				continue
+			}
+			if segment == "*" {
+				keys, items := wildcardItems(pv.Value)
+				for i, item := range items {
+					next = append(next, pathValue{Path: pv.Path + "." + keys[i], Value: item, Exists: true})
+				}
+				continue
+			}
+			value, exists := getChildValue(pv.Value, segment)
+			next = append(next, pathValue{Path: pv.Path + "." + segment, Value: value, Exists: exists})
+		}
+		values = next
+	}
+	return values
+}
+
+// 获取通配符“*”匹配的所有数据
+// 切片、数组返回所有元素及其下标，map返回所有值及其键（按键排序）
+func wildcardItems(value interface{}) (keys []string, items []interface{}) {
+	v := indirectValue(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		keys = make([]string, v.Len())
+		items = make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			keys[i] = strconv.Itoa(i)
+			items[i] = v.Index(i).Interface()
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		mapKeys := v.MapKeys()
+		sort.Slice(mapKeys, func(i, j int) bool {
+			return mapKeys[i].String() < mapKeys[j].String()
+		})
+		keys = make([]string, len(mapKeys))
+		items = make([]interface{}, len(mapKeys))
+		for i, mapKey := range mapKeys {
+			keys[i] = mapKey.String()
+			items[i] = v.MapIndex(mapKey).Interface()
+		}
+	}
+	return
+}
+
+// 获取字段标题
+// 依次查找：完整路径（如tags.3）、定义验证规则的路径（如tags.*）定义的标题，
+// 未定义时根据上级字段标题生成元素标题（如：标签第4项、评分[math]），否则为完整路径
+// locale 生成元素标题的语言
+func fieldTitle(titles map[string]string, path string, key string, locale string) string {
+	if title := titles[path]; title != "" {
+		return title
+	}
+	if title := titles[key]; title != "" {
+		return title
+	}
+	segments := strings.Split(path, ".")
+	keySegments := strings.Split(key, ".")
+	for i := len(segments) - 1; i > 0; i-- {
+		title := titles[strings.Join(segments[:i], ".")]
+		if title == "" {
+			continue
+		}
+		// 剩余路径需均为切片下标或通配符匹配的map键
+		for j, segment := range segments[i:] {
+			if index, err := strconv.Atoi(segment); err == nil {
+				title = ruleError("title.index", title, "index", index+1).Message(locale)
+				continue
+			}
+			if len(keySegments) == len(segments) && keySegments[i+j] == "*" {
+				title = ruleError("title.key", title, "key", segment).Message(locale)
+				continue
+			}
+			return path
+		}
+		return title
+	}
+	return path
+}
+
+// 获取下级数据
+// map根据键获取，结构体根据json标签（无json标签时根据属性名称）获取，切片、数组根据下标获取
+func getChildValue(value interface{}, key string) (child interface{}, exists bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		child, exists = m[key]
+		return
+	}
+	v := indirectValue(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		item := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !item.IsValid() {
+			return
+		}
+		return item.Interface(), true
+	case reflect.Struct:
+		if fieldIndex := structFieldIndex(v.Type(), key); fieldIndex != -1 {
+			return v.Field(fieldIndex).Interface(), true
+		}
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= v.Len() {
+			return
+		}
+		return v.Index(index).Interface(), true
+	}
+	return
+}
+
+// 获取结构体属性下标，根据json标签（无json标签时根据属性名称），未导出的属性除外，不存在时返回-1
+func structFieldIndex(t reflect.Type, key string) int {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Tag.Get("json")
+		if commaIndex := strings.Index(name, ","); commaIndex != -1 {
+			name = name[:commaIndex]
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// 获取指针、接口指向的实际值
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// 拼接路径
+func joinPath(prefix string, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" {
+		return prefix
+	}
+	return prefix + "." + path
+}
@@ -0,0 +1,299 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 缓存的最大条目数，超出后不再缓存（避免动态生成的规则字符串、参数占用过多内存）
+const maxCacheEntries = 4096
+
+// 有上限的并发安全缓存
+type boundedCache struct {
+	m sync.Map
+	n int64
+}
+
+// 获取缓存
+func (c *boundedCache) Load(key interface{}) (value interface{}, ok bool) {
+	return c.m.Load(key)
+}
+
+// 设置缓存，超出上限时不缓存
+func (c *boundedCache) Store(key interface{}, value interface{}) {
+	if atomic.LoadInt64(&c.n) >= maxCacheEntries {
+		return
+	}
+	if _, loaded := c.m.LoadOrStore(key, value); !loaded {
+		atomic.AddInt64(&c.n, 1)
+	}
+}
+
+// 验证器类型的验证计划，按验证器结构体类型缓存，同一类型的验证器实例共用（只读）
+type validatorPlan struct {
+	fields      []planField            // 有json标签的属性（按属性定义顺序）
+	fieldKeys   []string               // 结构体属性json标签（按属性定义顺序）
+	tagRules    map[string]interface{} // 结构体属性validate标签定义的验证规则
+	tagMessages map[string]string      // 结构体属性msg标签定义的验证提示信息
+	tagTitles   map[string]string      // 结构体属性title标签定义的验证字段标题
+	childFields map[string]childField  // 子验证器属性（键为json标签）
+	ruleChains  boundedCache           // 解析后的验证规则字符串（规则字符串 => *ruleChain）
+	methods     sync.Map               // 验证器方法规则（方法名称 => *methodPlan）
+}
+
+// 验证计划中的属性
+type planField struct {
+	Index int    // 属性下标
+	Key   string // json标签
+}
+
+// 解析后的验证规则字符串
+type ruleChain struct {
+	items []ruleItem
+	err   error
+}
+
+// 验证器方法规则
+type methodPlan struct {
+	index  int   // 方法下标（验证器结构体指针类型的方法）
+	ctx    bool  // 方法第1个参数是否为context.Context
+	direct bool  // 方法签名是否可直接断言为函数调用（无需反射调用）
+	err    error // 方法定义错误
+}
+
+// 验证器方法规则的函数类型
+type methodRuleFunc = func(value interface{}, param string, datas map[string]interface{}, title string) error
+
+// 验证器方法规则的函数类型（可获取上下文）
+type methodRuleCtxFunc = func(ctx context.Context, value interface{}, param string, datas map[string]interface{}, title string) error
+
+// 上下文类型
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// 各验证器类型的验证计划（验证器结构体类型 => *validatorPlan）
+var validatorPlans sync.Map
+
+// 获取验证器类型的验证计划，首次获取时解析结构体标签
+func planOf(t reflect.Type) *validatorPlan {
+	if plan, ok := validatorPlans.Load(t); ok {
+		return plan.(*validatorPlan)
+	}
+	plan, _ := validatorPlans.LoadOrStore(t, newValidatorPlan(t))
+	return plan.(*validatorPlan)
+}
+
+// 解析验证器结构体类型，生成验证计划
+func newValidatorPlan(t reflect.Type) *validatorPlan {
+	plan := &validatorPlan{
+		fieldKeys:   []string{},
+		tagRules:    map[string]interface{}{},
+		tagMessages: map[string]string{},
+		tagTitles:   map[string]string{},
+		childFields: map[string]childField{},
+	}
+	for i := 0; i < t.NumField(); i++ {
+		typeField := t.Field(i)
+		// 获取 JSON 标签
+		jsonTag := typeField.Tag.Get("json")
+		// 解析 JSON 标签，处理可能的选项，如 omitempty
+		if commaIndex := strings.Index(jsonTag, ","); commaIndex != -1 {
+			jsonTag = jsonTag[:commaIndex]
+		}
+		// 如果 JSON 标签不为空，则使用该标签作为键
+		if jsonTag == "" {
+			continue
+		}
+		plan.fields = append(plan.fields, planField{Index: i, Key: jsonTag})
+		plan.fieldKeys = append(plan.fieldKeys, jsonTag)
+		// 子验证器
+		if isChildValidatorType(typeField.Type) {
+			plan.childFields[jsonTag] = childField{Index: i, Scene: typeField.Tag.Get("scene")}
+		}
+		// 验证规则
+		if ruleTag, ok := typeField.Tag.Lookup("validate"); ok {
+			plan.tagRules[jsonTag] = ruleTag
+		}
+		// 验证字段标题
+		if titleTag := typeField.Tag.Get("title"); titleTag != "" {
+			plan.tagTitles[jsonTag] = titleTag
+		}
+		// 验证提示信息
+		for _, msgItem := range strings.Split(typeField.Tag.Get("msg"), "|") {
+			colonIndex := strings.Index(msgItem, ":")
+			if colonIndex <= 0 {
+				continue
+			}
+			plan.tagMessages[jsonTag+"."+msgItem[:colonIndex]] = msgItem[colonIndex+1:]
+		}
+	}
+	return plan
+}
+
+// 获取解析后的验证规则字符串
+func (p *validatorPlan) ruleItems(rule string) ([]ruleItem, error) {
+	return parseRuleStringCached(&p.ruleChains, rule)
+}
+
+// 获取验证器方法规则，首次获取时检查方法定义
+// ptrType 验证器结构体指针类型
+func (p *validatorPlan) method(ptrType reflect.Type, methodName string) *methodPlan {
+	if plan, ok := p.methods.Load(methodName); ok {
+		return plan.(*methodPlan)
+	}
+	plan, _ := p.methods.LoadOrStore(methodName, newMethodPlan(ptrType, methodName))
+	return plan.(*methodPlan)
+}
+
+// 检查验证器方法规则的定义
+func newMethodPlan(ptrType reflect.Type, methodName string) *methodPlan {
+	// 指针类型可以调用值接收者方法和指针接收者方法
+	method, ok := ptrType.MethodByName(methodName)
+	if !ok || method.PkgPath != "" {
+		return &methodPlan{err: fmt.Errorf("方法%s不可调用", methodName)}
+	}
+	// 方法类型（第1个参数为接收者）
+	methodType := method.Type
+	methodParamNum := methodType.NumIn() - 1
+	// 第1个参数为context.Context时，其余参数与不含上下文的方法一致
+	offset := 1
+	withCtx := methodParamNum == 5 && methodType.In(1) == contextType
+	if withCtx {
+		offset = 2
+		methodParamNum--
+	}
+	// 检查参数数量
+	if methodParamNum != 4 {
+		return &methodPlan{err: fmt.Errorf("方法%s需定义4个参数（或第1个参数为context.Context的5个参数），但实际有%d个参数", methodName, methodType.NumIn()-1)}
+	}
+	// 检查参数类型
+	no1MethodParamType := methodType.In(offset)
+	if no1MethodParamType.Kind() != reflect.Interface || no1MethodParamType.NumMethod() != 0 {
+		return &methodPlan{err: fmt.Errorf("方法%s的第%d个参数类型不正确，需为interface{}", methodName, offset)}
+	}
+	no2MethodParamType := methodType.In(offset + 1)
+	if no2MethodParamType.Kind() != reflect.String {
+		return &methodPlan{err: fmt.Errorf("方法%s的第%d个参数类型不正确，需为string", methodName, offset+1)}
+	}
+	no3MethodParamType := methodType.In(offset + 2)
+	if no3MethodParamType.Kind() != reflect.Map || no3MethodParamType.Key().Kind() != reflect.String || no3MethodParamType.Elem().Kind() != reflect.Interface {
+		return &methodPlan{err: fmt.Errorf("方法%s的第%d个参数类型不正确，需为map[string]interface{}", methodName, offset+2)}
+	}
+	no4MethodParamType := methodType.In(offset + 3)
+	if no4MethodParamType.Kind() != reflect.String {
+		return &methodPlan{err: fmt.Errorf("方法%s的第%d个参数类型不正确，需为string", methodName, offset+3)}
+	}
+	// 检查返回值数量
+	methodRetuenNum := methodType.NumOut()
+	if methodRetuenNum != 1 {
+		return &methodPlan{err: fmt.Errorf("方法%s只需返回1个错误结果，但实际有%d个变量返回", methodName, methodRetuenNum)}
+	}
+	// 检查返回值类型
+	if methodType.Out(0) != reflect.TypeOf((*error)(nil)).Elem() {
+		return &methodPlan{err: fmt.Errorf("方法%s的返回值类型不正确，需为error", methodName)}
+	}
+	in := make([]reflect.Type, 0, methodType.NumIn()-1)
+	for i := 1; i < methodType.NumIn(); i++ {
+		in = append(in, methodType.In(i))
+	}
+	funcType := reflect.FuncOf(in, []reflect.Type{methodType.Out(0)}, false)
+	direct := funcType == reflect.TypeOf(methodRuleFunc(nil))
+	if withCtx {
+		direct = funcType == reflect.TypeOf(methodRuleCtxFunc(nil))
+	}
+	return &methodPlan{index: method.Index, ctx: withCtx, direct: direct}
+}
+
+// CheckVar使用的验证规则字符串缓存
+var varRuleChains boundedCache
+
+// 解析验证规则字符串并缓存
+func parseRuleStringCached(cache *boundedCache, rule string) ([]ruleItem, error) {
+	if chain, ok := cache.Load(rule); ok {
+		return chain.(*ruleChain).items, chain.(*ruleChain).err
+	}
+	items, err := parseRuleString(rule)
+	cache.Store(rule, &ruleChain{items: items, err: err})
+	return items, err
+}
+
+// 内置规则参数缓存
+var (
+	paramsCache    boundedCache // “,”间隔的参数（参数 => *paramsCacheItem）
+	intParamsCache boundedCache // 整数参数（参数 => *intParamsCacheItem）
+	regexpCache    boundedCache // 正则表达式参数（参数 => *regexp.Regexp）
+)
+
+// “,”间隔的参数缓存
+type paramsCacheItem struct {
+	params []string
+	err    error
+}
+
+// 整数参数缓存
+type intParamsCacheItem struct {
+	params []int
+	err    error
+}
+
+// 解析“,”间隔的验证规则参数并缓存（返回的切片共用，不可修改）
+func cachedParams(param string) ([]string, error) {
+	if item, ok := paramsCache.Load(param); ok {
+		return item.(*paramsCacheItem).params, item.(*paramsCacheItem).err
+	}
+	params, err := ParseParams(param)
+	paramsCache.Store(param, &paramsCacheItem{params: params, err: err})
+	return params, err
+}
+
+// 解析“,”间隔的整数验证规则参数并缓存（返回的切片共用，不可修改）
+func cachedIntParams(param string) ([]int, error) {
+	if item, ok := intParamsCache.Load(param); ok {
+		return item.(*intParamsCacheItem).params, item.(*intParamsCacheItem).err
+	}
+	item := &intParamsCacheItem{}
+	params, err := cachedParams(param)
+	if err != nil {
+		item.err = err
+	} else {
+		item.params = make([]int, len(params))
+		for i, p := range params {
+			if item.params[i], err = strconv.Atoi(p); err != nil {
+				item.params, item.err = nil, err
+				break
+			}
+		}
+	}
+	intParamsCache.Store(param, item)
+	return item.params, item.err
+}
+
+// 解析整数验证规则参数并缓存
+func cachedIntParam(param string) (int, error) {
+	params, err := cachedIntParams(param)
+	if err != nil {
+		return 0, err
+	}
+	if len(params) != 1 {
+		return 0, fmt.Errorf("参数%s需为整数", param)
+	}
+	return params[0], nil
+}
+
+// 编译正则表达式验证规则参数并缓存
+func cachedRegexp(ruleName string, param string) (*regexp.Regexp, error) {
+	if re, ok := regexpCache.Load(param); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := paramRegexp(ruleName, param)
+	if err != nil {
+		return nil, err
+	}
+	regexpCache.Store(param, re)
+	return re, nil
+}
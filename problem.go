@@ -0,0 +1,137 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// 问题详情响应的Content-Type
+const ProblemContentType = "application/problem+json"
+
+// 客户端关闭请求的状态码（非标准状态码，请求的上下文取消时返回）
+const StatusClientClosedRequest = 499
+
+// 问题详情（RFC 7807）
+type Problem struct {
+	Type     string         `json:"type"`               // 问题类型URI
+	Title    string         `json:"title"`              // 问题标题
+	Status   int            `json:"status"`             // HTTP状态码
+	Detail   string         `json:"detail,omitempty"`   // 问题详情
+	Instance string         `json:"instance,omitempty"` // 发生问题的请求路径
+	Errors   []ProblemError `json:"errors,omitempty"`   // 验证失败的字段错误（扩展成员）
+}
+
+// 问题详情中的字段错误
+type ProblemError struct {
+	Field   string `json:"field"`          // 字段（完整路径，如：items.0.sku）
+	Rule    string `json:"rule,omitempty"` // 验证规则名称
+	Message string `json:"message"`        // 提示信息
+}
+
+// 问题详情渲染器，将验证错误渲染为application/problem+json响应
+// 验证失败、请求数据错误返回对应状态码及字段错误（请求数据错误不返回原始错误信息），请求的上下文取消、超时返回499、503，
+// 系统错误及其他错误返回500且不返回内部错误信息
+type ProblemRenderer struct {
+	Type             string                           // 验证失败、请求数据错误的问题类型URI，默认为about:blank（系统错误始终为about:blank）
+	ValidationStatus int                              // 验证失败的状态码，默认为422
+	BindStatus       int                              // 请求数据错误（*BindError）的状态码，默认为400（请求体超出大小限制固定为413，Content-Type不支持固定为415）
+	SystemDetail     string                           // 系统错误及其他错误返回的问题详情，默认为空
+	OnError          func(r *http.Request, err error) // 系统错误及其他错误的回调（如记录日志），上下文取消、超时不回调
+}
+
+// 默认问题详情渲染器
+var DefaultProblemRenderer = &ProblemRenderer{}
+
+// 根据错误生成问题详情
+func (p *ProblemRenderer) Problem(r *http.Request, err error) *Problem {
+	problem := &Problem{Type: "about:blank"}
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+	var bindErr *BindError
+	switch {
+	case isContextError(err):
+		// 客户端取消请求、验证超时不是系统错误
+		problem.Status = StatusClientClosedRequest
+		if errors.Is(err, context.DeadlineExceeded) {
+			problem.Status = http.StatusServiceUnavailable
+		}
+	case errors.As(err, &bindErr):
+		if p.Type != "" {
+			problem.Type = p.Type
+		}
+		switch {
+		case errors.Is(err, ErrBodyTooLarge):
+			problem.Status = http.StatusRequestEntityTooLarge
+		case errors.Is(err, ErrUnsupportedMediaType):
+			problem.Status = http.StatusUnsupportedMediaType
+		default:
+			problem.Status = p.BindStatus
+			if problem.Status == 0 {
+				problem.Status = http.StatusBadRequest
+			}
+		}
+		// 原始错误可能包含内部信息（如类型名称），只返回概括的错误信息
+		problem.Detail = bindErr.message()
+		if bindErr.Field != "" {
+			problem.Errors = []ProblemError{{Field: bindErr.Field, Message: problem.Detail}}
+		}
+	case !errors.Is(err, ErrSystem) && errors.Is(err, ErrValidation):
+		if p.Type != "" {
+			problem.Type = p.Type
+		}
+		problem.Status = p.ValidationStatus
+		if problem.Status == 0 {
+			problem.Status = http.StatusUnprocessableEntity
+		}
+		problem.Detail = err.Error()
+		for _, fieldErr := range resultErrors(err) {
+			problem.Errors = append(problem.Errors, ProblemError{Field: fieldErr.Field, Rule: fieldErr.Rule, Message: fieldErr.Message})
+		}
+	default:
+		// 系统错误等不返回内部错误信息
+		if p.OnError != nil {
+			p.OnError(r, err)
+		}
+		problem.Status = http.StatusInternalServerError
+		problem.Detail = p.SystemDetail
+	}
+	problem.Title = http.StatusText(problem.Status)
+	if problem.Status == StatusClientClosedRequest {
+		problem.Title = "Client Closed Request"
+	}
+	return problem
+}
+
+// 渲染错误为问题详情响应，错误为nil时不渲染
+func (p *ProblemRenderer) Render(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+	problem := p.Problem(r, err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// 返回错误的请求处理函数
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// 中间件，处理函数返回错误时渲染为问题详情响应
+func (p *ProblemRenderer) Handler(handler HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.Render(w, r, handler(w, r))
+	})
+}
+
+// 使用默认问题详情渲染器渲染错误
+func RenderProblem(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultProblemRenderer.Render(w, r, err)
+}
+
+// 使用默认问题详情渲染器的中间件
+func ProblemHandler(handler HandlerFunc) http.Handler {
+	return DefaultProblemRenderer.Handler(handler)
+}
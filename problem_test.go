@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProblemContextError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		title  string
+	}{
+		{context.Canceled, StatusClientClosedRequest, "Client Closed Request"},
+		{fmt.Errorf("查询用户：%w", context.Canceled), StatusClientClosedRequest, "Client Closed Request"},
+		{context.DeadlineExceeded, http.StatusServiceUnavailable, "Service Unavailable"},
+	}
+	for _, test := range tests {
+		called := false
+		renderer := &ProblemRenderer{OnError: func(r *http.Request, err error) { called = true }}
+		problem := renderer.Problem(nil, test.err)
+		if problem.Status != test.status || problem.Title != test.title {
+			t.Errorf("Problem(%v) = %d %s，期望 %d %s", test.err, problem.Status, problem.Title, test.status, test.title)
+		}
+		if called {
+			t.Errorf("Problem(%v)调用了OnError", test.err)
+		}
+	}
+}
+
+func TestProblemBindErrorDetail(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{"title":"a","cate_id":"3"}`))
+	r.Header.Set("Content-Type", "application/json")
+	err := Bind(r, &bindTestArticle{}, "")
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("Bind返回错误 %v，期望 *BindError", err)
+	}
+	problem := DefaultProblemRenderer.Problem(r, err)
+	if problem.Status != http.StatusBadRequest || problem.Detail != "字段cate_id格式错误" {
+		t.Errorf("Problem = %d %q", problem.Status, problem.Detail)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "cate_id" || problem.Errors[0].Message != problem.Detail {
+		t.Errorf("Problem.Errors = %+v", problem.Errors)
+	}
+	// 原始错误保留在错误中
+	if !strings.Contains(err.Error(), "json: cannot unmarshal") {
+		t.Errorf("BindError = %v，期望包含原始错误", err)
+	}
+}
@@ -0,0 +1,207 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrRuleExists   = errors.New("rule already exists") // 验证规则已存在（可通过errors.Is判断）
+	ErrRuleNotFound = errors.New("rule not found")      // 验证规则不存在（可通过errors.Is判断）
+)
+
+// 验证规则注册表（并发安全）
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// 创建验证规则注册表
+func NewRegistry() *Registry {
+	return &Registry{rules: map[string]Rule{}}
+}
+
+// 检查验证规则定义
+func checkRuleDefine(rule Rule) (err error) {
+	if rule.Name == "" {
+		err = errors.New("rule name is empty")
+		return
+	}
+	if rule.Fun == nil && rule.ValidatorFun == nil && rule.CtxFun == nil {
+		err = fmt.Errorf("rule %s fun is empty", rule.Name)
+		return
+	}
+	return
+}
+
+// 复制注册表
+func (r *Registry) clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clone := &Registry{rules: make(map[string]Rule, len(r.rules))}
+	for name, rule := range r.rules {
+		clone.rules[name] = rule
+	}
+	return clone
+}
+
+// 注册规则，规则已存在时返回ErrRuleExists（覆盖已存在的规则请使用Replace）
+func (r *Registry) Register(rule Rule) (err error) {
+	err = checkRuleDefine(rule)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[rule.Name]; ok {
+		err = fmt.Errorf("%w: %s", ErrRuleExists, rule.Name)
+		return
+	}
+	r.rules[rule.Name] = rule
+	return
+}
+
+// 注册多个规则，遇到错误时停止注册
+func (r *Registry) RegisterRules(rules []Rule) (err error) {
+	for _, rule := range rules {
+		err = r.Register(rule)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 注册或覆盖规则
+func (r *Registry) Replace(rule Rule) (err error) {
+	err = checkRuleDefine(rule)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[rule.Name] = rule
+	return
+}
+
+// 注销规则，规则不存在时返回ErrRuleNotFound
+func (r *Registry) Unregister(name string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[name]; !ok {
+		err = fmt.Errorf("%w: %s", ErrRuleNotFound, name)
+		return
+	}
+	delete(r.rules, name)
+	return
+}
+
+// 获取规则
+func (r *Registry) Lookup(name string) (rule Rule, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok = r.rules[name]
+	return
+}
+
+// 判断规则是否存在
+func (r *Registry) Has(name string) bool {
+	_, ok := r.Lookup(name)
+	return ok
+}
+
+// 获取所有规则名称（按名称排序）
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.rules))
+	for name := range r.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 获取所有规则（按名称排序）
+func (r *Registry) List() []Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// 验证规则查找范围，依次从各注册表查找（如：单次验证的规则、验证器的局部规则、默认注册表）
+type ruleScope []*Registry
+
+// 创建验证规则查找范围，默认注册表始终最后查找
+func newRuleScope(registries ...*Registry) ruleScope {
+	scope := make(ruleScope, 0, len(registries)+1)
+	for _, registry := range registries {
+		if registry != nil {
+			scope = append(scope, registry)
+		}
+	}
+	return append(scope, DefaultRegistry)
+}
+
+// 获取规则
+func (s ruleScope) Lookup(name string) (rule Rule, ok bool) {
+	for _, registry := range s {
+		if rule, ok = registry.Lookup(name); ok {
+			return
+		}
+	}
+	return
+}
+
+// 判断是否为必填类规则
+func (s ruleScope) isPresenceRule(name string) bool {
+	rule, ok := s.Lookup(name)
+	return ok && rule.Presence
+}
+
+// 默认验证规则注册表（包含内置验证规则，RegisterRule等函数注册的规则）
+var DefaultRegistry = newDefaultRegistry()
+
+// 创建默认验证规则注册表
+func newDefaultRegistry() *Registry {
+	registry := &Registry{rules: make(map[string]Rule, len(builtinRules))}
+	for name, rule := range builtinRules {
+		rule.Name = name
+		registry.rules[name] = rule
+	}
+	return registry
+}
+
+// 注册规则到默认注册表，规则已存在时返回ErrRuleExists（覆盖内置规则请使用ReplaceRule）
+func RegisterRule(rule Rule) (err error) {
+	return DefaultRegistry.Register(rule)
+}
+
+// 注册多个规则到默认注册表，遇到已存在的规则时返回ErrRuleExists并停止注册（覆盖规则请使用ReplaceRule）
+func RegisterRules(rules []Rule) (err error) {
+	return DefaultRegistry.RegisterRules(rules)
+}
+
+// 注册或覆盖默认注册表的规则
+func ReplaceRule(rule Rule) (err error) {
+	return DefaultRegistry.Replace(rule)
+}
+
+// 从默认注册表注销规则
+func UnregisterRule(name string) (err error) {
+	return DefaultRegistry.Unregister(name)
+}
+
+// 从默认注册表获取规则
+func LookupRule(name string) (rule Rule, ok bool) {
+	return DefaultRegistry.Lookup(name)
+}
@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// 验证结果
+type Result struct {
+	Valid     bool                   // 是否验证通过
+	Scene     string                 // 验证场景
+	Err       error                  // 验证错误（验证通过时为nil），可能为系统错误、上下文取消或超时错误
+	Errors    []*ValidationError     // 验证失败的字段错误（按验证顺序，系统错误、上下文错误时为空）
+	Datas     map[string]interface{} // 验证通过后的数据（经HandleDatas处理），仅包含当前验证的字段
+	Duration  time.Duration          // 验证耗时
+	Validator ValidatorInterface     // 本次验证的验证器实例
+}
+
+// 创建验证结果
+// checkKeys 当前验证字段，datas 验证后的数据
+func newResult(validator ValidatorInterface, scene string, err error, checkKeys []string, datas map[string]interface{}) *Result {
+	result := &Result{
+		Valid:     err == nil,
+		Scene:     scene,
+		Err:       err,
+		Validator: validator,
+	}
+	if err != nil {
+		result.Errors = resultErrors(err)
+		return result
+	}
+	result.Datas = make(map[string]interface{}, len(checkKeys))
+	for _, key := range checkKeys {
+		// 多级路径（如items.*.sku）返回顶级字段的数据
+		if index := strings.Index(key, "."); index != -1 {
+			key = key[:index]
+		}
+		if value, ok := datas[key]; ok {
+			result.Datas[key] = value
+		}
+	}
+	return result
+}
+
+// 获取验证失败的字段错误
+func resultErrors(err error) []*ValidationError {
+	if errors.Is(err, ErrSystem) || isContextError(err) {
+		return nil
+	}
+	if errs, ok := err.(*Errors); ok {
+		return errs.ValidationErrors()
+	}
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return []*ValidationError{ve}
+	}
+	return []*ValidationError{{Message: err.Error(), Err: err}}
+}
+
+// 验证指定场景的数据并返回验证结果
+// scene 验证场景，为空时验证全部规则
+func (v *Validator) Validate(scene string, opts ...CheckOption) *Result {
+	return v.ValidateContext(context.Background(), scene, opts...)
+}
+
+// 验证指定场景的数据并返回验证结果，上下文取消、超时时中止验证
+func (v *Validator) ValidateContext(ctx context.Context, scene string, opts ...CheckOption) *Result {
+	start := time.Now()
+	err := v.CheckSceneContext(ctx, scene, opts...)
+	var datas map[string]interface{}
+	if err == nil {
+		datas, _ = v.GetDatas()
+	}
+	result := newResult(v.validatorInstance, scene, err, v.CheckKeys, datas)
+	result.Duration = time.Since(start)
+	return result
+}
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,8 +11,12 @@ import (
 
 // 验证其规则
 type Rule struct {
-	Name string                                                                                  // 规则名称
-	Fun  func(value interface{}, param string, datas map[string]interface{}, title string) error // 校验方法
+	Name         string                                                                                                                // 规则名称
+	Fun          func(value interface{}, param string, datas map[string]interface{}, title string) error                               // 校验方法
+	ValidatorFun func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error // 可获取验证器实例的校验方法（如获取其他字段标题），定义后优先于Fun
+	CtxFun       func(ctx context.Context, value interface{}, param string, datas map[string]interface{}, title string) error          // 可获取上下文的校验方法（如查询数据库时遵循请求的取消、超时），定义后优先于ValidatorFun、Fun
+	Presence     bool                                                                                                                  // 是否为必填类规则（数据为空时只验证必填类规则，其他规则不验证）
+	Messages     map[string]string                                                                                                     // 各语言的提示信息模板（语言 => 模板，如："en": "{title} is invalid"），规则返回*RuleError时使用其提示信息键翻译
 
 	validator ValidatorInterface // 验证器实例
 }
@@ -24,46 +29,144 @@ func (r *Rule) SetValidator(validator ValidatorInterface) *Rule {
 
 // 校验
 func (r *Rule) Check(value interface{}, param string, datas map[string]interface{}, title string) (err error) {
-	err = r.Fun(value, param, datas, title)
-	return
+	return r.CheckContext(context.Background(), value, param, datas, title)
 }
 
-// 注册规则
-func RegisterRule(rule Rule) (err error) {
-	if rule.Name == "" {
-		err = errors.New("rule name is empty")
+// 校验（上下文传递给CtxFun）
+func (r *Rule) CheckContext(ctx context.Context, value interface{}, param string, datas map[string]interface{}, title string) (err error) {
+	if r.CtxFun != nil {
+		err = r.CtxFun(ctx, value, param, datas, title)
 		return
 	}
-	Rules[rule.Name] = rule
-	return
-}
-
-// 注册多个规则
-func RegisterRules(rule []Rule) (err error) {
-	for _, v := range rule {
-		err = RegisterRule(v)
-		if err != nil {
-			return
-		}
+	if r.ValidatorFun != nil {
+		err = r.ValidatorFun(r.validator, value, param, datas, title)
+		return
 	}
+	err = r.Fun(value, param, datas, title)
 	return
 }
 
-var Rules = map[string]Rule{
+// 内置验证规则（用于初始化默认注册表DefaultRegistry）
+var builtinRules = map[string]Rule{
 	"required": {
 		Name: "required",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if isEmpty(value) {
-				return errors.New(title + "不能为空")
+				return ruleError("required", title)
+			}
+			return nil
+		},
+		Presence: true,
+	},
+	"requiredIf": {
+		Name: "requiredIf",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
+			}
+			if len(rule) < 2 || rule[0] == "" {
+				return newSystemError("验证规则[requiredIf]参数需为“,”间隔的字段及值")
+			}
+			fieldValue, _ := getValueByPath(datas, rule[0])
+			if inArray(fmt.Sprintf("%v", fieldValue), rule[1:]) && isEmpty(value) {
+				return ruleError("required", title)
 			}
 			return nil
 		},
+		Presence: true,
+	},
+	"requiredUnless": {
+		Name: "requiredUnless",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
+			}
+			if len(rule) < 2 || rule[0] == "" {
+				return newSystemError("验证规则[requiredUnless]参数需为“,”间隔的字段及值")
+			}
+			fieldValue, _ := getValueByPath(datas, rule[0])
+			if !inArray(fmt.Sprintf("%v", fieldValue), rule[1:]) && isEmpty(value) {
+				return ruleError("required", title)
+			}
+			return nil
+		},
+		Presence: true,
+	},
+	"requiredWith": {
+		Name: "requiredWith",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if param == "" {
+				return newSystemError("验证规则[requiredWith]错误")
+			}
+			fields, err := cachedParams(param)
+			if err != nil {
+				return err
+			}
+			if presentFieldNum(datas, fields) > 0 && isEmpty(value) {
+				return ruleError("required", title)
+			}
+			return nil
+		},
+		Presence: true,
+	},
+	"requiredWithAll": {
+		Name: "requiredWithAll",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if param == "" {
+				return newSystemError("验证规则[requiredWithAll]错误")
+			}
+			fields, err := cachedParams(param)
+			if err != nil {
+				return err
+			}
+			if presentFieldNum(datas, fields) == len(fields) && isEmpty(value) {
+				return ruleError("required", title)
+			}
+			return nil
+		},
+		Presence: true,
+	},
+	"requiredWithout": {
+		Name: "requiredWithout",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if param == "" {
+				return newSystemError("验证规则[requiredWithout]错误")
+			}
+			fields, err := cachedParams(param)
+			if err != nil {
+				return err
+			}
+			if presentFieldNum(datas, fields) < len(fields) && isEmpty(value) {
+				return ruleError("required", title)
+			}
+			return nil
+		},
+		Presence: true,
+	},
+	"requiredWithoutAll": {
+		Name: "requiredWithoutAll",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			if param == "" {
+				return newSystemError("验证规则[requiredWithoutAll]错误")
+			}
+			fields, err := cachedParams(param)
+			if err != nil {
+				return err
+			}
+			if presentFieldNum(datas, fields) == 0 && isEmpty(value) {
+				return ruleError("required", title)
+			}
+			return nil
+		},
+		Presence: true,
 	},
 	"number": {
 		Name: "number",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isNumeric(value) {
-				return errors.New(title + "需由数字组成")
+				return ruleError("number", title)
 			}
 			return nil
 		},
@@ -80,14 +183,14 @@ var Rules = map[string]Rule{
 					return nil
 				}
 			}
-			return errors.New(title + "需为整数")
+			return ruleError("integer", title)
 		},
 	},
 	"positiveInt": {
 		Name: "positiveInt",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isPositiveInt(value) {
-				return errors.New(title + "需为正整数")
+				return ruleError("positiveInt", title)
 			}
 			return nil
 		},
@@ -96,7 +199,7 @@ var Rules = map[string]Rule{
 		Name: "nonnegativeInt",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isNonnegativeInt(value) {
-				return errors.New(title + "需为非负整数")
+				return ruleError("nonnegativeInt", title)
 			}
 			return nil
 		},
@@ -105,7 +208,7 @@ var Rules = map[string]Rule{
 		Name: "float",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isFloat(value) {
-				return errors.New(title + "需为浮点数")
+				return ruleError("float", title)
 			}
 			return nil
 		},
@@ -114,7 +217,7 @@ var Rules = map[string]Rule{
 		Name: "boolean",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isBool(value) {
-				return errors.New(title + "需为布尔值")
+				return ruleError("boolean", title)
 			}
 			return nil
 		},
@@ -124,33 +227,29 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			valStr, ok := value.(string)
 			if !ok {
-				return errors.New("需为字符串形式")
+				return ruleError("string", title)
 			}
 			if param == "" {
-				return errors.New("验证规则[length]错误")
+				return newSystemError("验证规则[length]错误")
 			}
 			valLen := strCharNum(valStr)
 			if !strings.Contains(param, ",") {
-				limitLen, err := strconv.Atoi(param)
+				limitLen, err := cachedIntParam(param)
 				if err != nil || !isPositiveInt(limitLen) {
-					return errors.New("验证规则[length]参数需为正整数")
+					return newSystemError("验证规则[length]参数需为正整数")
 				}
 				if valLen != limitLen {
-					return errors.New(title + fmt.Sprintf("限制长度%d", limitLen))
+					return ruleError("length", title, "length", limitLen)
 				}
 				return nil
 			}
-			limitLenArr := strings.Split(param, ",")
-			if len(limitLenArr) != 2 {
-				return errors.New("验证规则[length]参数需为“,”间隔的两个正整数")
-			}
-			limitMinLen, err1 := strconv.Atoi(limitLenArr[0])
-			limitMaxLen, err2 := strconv.Atoi(limitLenArr[1])
-			if err1 != nil || err2 != nil || !isPositiveInt(limitMinLen) || !isPositiveInt(limitMaxLen) {
-				return errors.New("验证规则[length]参数需为“,”间隔的两个正整数")
+			limitLenArr, err := cachedIntParams(param)
+			if err != nil || len(limitLenArr) != 2 || !isPositiveInt(limitLenArr[0]) || !isPositiveInt(limitLenArr[1]) {
+				return newSystemError("验证规则[length]参数需为“,”间隔的两个正整数")
 			}
+			limitMinLen, limitMaxLen := limitLenArr[0], limitLenArr[1]
 			if valLen < limitMinLen || valLen > limitMaxLen {
-				return errors.New(title + fmt.Sprintf("限制长度区间%d-%d", limitMinLen, limitMaxLen))
+				return ruleError("length.range", title, "min", limitMinLen, "max", limitMaxLen)
 			}
 			return nil
 		},
@@ -160,15 +259,15 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			valStr, ok := value.(string)
 			if !ok {
-				return errors.New("需为字符串形式")
+				return ruleError("string", title)
 			}
-			minLen, err := strconv.Atoi(param)
+			minLen, err := cachedIntParam(param)
 			if err != nil {
-				return errors.New("验证规则[min]参数错误")
+				return newSystemError("验证规则[min]参数错误")
 			}
 			valLen := strCharNum(valStr)
 			if valLen < minLen {
-				return errors.New(title + fmt.Sprintf("限制最小长度%d", minLen))
+				return ruleError("min", title, "min", minLen)
 			}
 			return nil
 		},
@@ -178,15 +277,15 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			valStr, ok := value.(string)
 			if !ok {
-				return errors.New("需为字符串形式")
+				return ruleError("string", title)
 			}
-			maxLen, err := strconv.Atoi(param)
+			maxLen, err := cachedIntParam(param)
 			if err != nil {
-				return errors.New("验证规则[max]参数错误")
+				return newSystemError("验证规则[max]参数错误")
 			}
 			valLen := strCharNum(valStr)
 			if valLen > maxLen {
-				return errors.New(title + fmt.Sprintf("限制最大长度%d", maxLen))
+				return ruleError("max", title, "max", maxLen)
 			}
 			return nil
 		},
@@ -195,11 +294,14 @@ var Rules = map[string]Rule{
 		Name: "in",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[in]错误")
+				return newSystemError("验证规则[in]错误")
+			}
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			rule := strings.Split(param, ",")
 			if len(rule) == 0 {
-				return errors.New("验证规则[in]错误")
+				return newSystemError("验证规则[in]错误")
 			}
 			valStr := fmt.Sprintf("%v", value)
 			for _, r := range rule {
@@ -207,23 +309,26 @@ var Rules = map[string]Rule{
 					return nil
 				}
 			}
-			return errors.New(title + "错误")
+			return ruleError("invalid", title)
 		},
 	},
 	"notIn": {
 		Name: "notIn",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[notIn]错误")
+				return newSystemError("验证规则[notIn]错误")
+			}
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			rule := strings.Split(param, ",")
 			if len(rule) == 0 {
-				return errors.New("验证规则[notIn]错误")
+				return newSystemError("验证规则[notIn]错误")
 			}
 			valStr := fmt.Sprintf("%v", value)
 			for _, r := range rule {
 				if r == valStr {
-					return errors.New(title + "错误")
+					return ruleError("invalid", title)
 				}
 			}
 			return nil
@@ -233,23 +338,25 @@ var Rules = map[string]Rule{
 		Name: "between",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[between]错误")
+				return newSystemError("验证规则[between]错误")
 			}
-			rule := strings.Split(param, ",")
-			if len(rule) != 2 {
-				return errors.New("验证规则[between]错误")
+			rule, err := cachedIntParams(param)
+			if err != nil {
+				if errors.Is(err, ErrSystem) {
+					return err
+				}
+				return newSystemError("验证规则[between]参数错误")
 			}
-			min, err1 := strconv.Atoi(rule[0])
-			max, err2 := strconv.Atoi(rule[1])
-			if err1 != nil || err2 != nil {
-				return errors.New("验证规则[between]参数错误")
+			if len(rule) != 2 {
+				return newSystemError("验证规则[between]错误")
 			}
+			min, max := rule[0], rule[1]
 			num, err := strconv.Atoi(fmt.Sprintf("%v", value))
 			if err != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if num < min || num > max {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -258,23 +365,25 @@ var Rules = map[string]Rule{
 		Name: "notBetween",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[notBetween]错误")
+				return newSystemError("验证规则[notBetween]错误")
 			}
-			rule := strings.Split(param, ",")
-			if len(rule) != 2 {
-				return errors.New("验证规则[notBetween]错误")
+			rule, err := cachedIntParams(param)
+			if err != nil {
+				if errors.Is(err, ErrSystem) {
+					return err
+				}
+				return newSystemError("验证规则[notBetween]参数错误")
 			}
-			min, err1 := strconv.Atoi(rule[0])
-			max, err2 := strconv.Atoi(rule[1])
-			if err1 != nil || err2 != nil {
-				return errors.New("验证规则[notBetween]参数错误")
+			if len(rule) != 2 {
+				return newSystemError("验证规则[notBetween]错误")
 			}
+			min, max := rule[0], rule[1]
 			num, err := strconv.Atoi(fmt.Sprintf("%v", value))
 			if err != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if num >= min && num <= max {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -283,15 +392,15 @@ var Rules = map[string]Rule{
 		Name: "eq",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[eq]错误")
+				return newSystemError("验证规则[eq]错误")
 			}
 			valNum, err1 := strconv.Atoi(fmt.Sprintf("%v", value))
-			ruleNum, err2 := strconv.Atoi(param)
+			ruleNum, err2 := cachedIntParam(param)
 			if err1 != nil || err2 != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if valNum != ruleNum {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -300,15 +409,15 @@ var Rules = map[string]Rule{
 		Name: "egt",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[egt]错误")
+				return newSystemError("验证规则[egt]错误")
 			}
 			valNum, err1 := strconv.Atoi(fmt.Sprintf("%v", value))
-			ruleNum, err2 := strconv.Atoi(param)
+			ruleNum, err2 := cachedIntParam(param)
 			if err1 != nil || err2 != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if valNum < ruleNum {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -317,15 +426,15 @@ var Rules = map[string]Rule{
 		Name: "gt",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[gt]错误")
+				return newSystemError("验证规则[gt]错误")
 			}
 			valNum, err1 := strconv.Atoi(fmt.Sprintf("%v", value))
-			ruleNum, err2 := strconv.Atoi(param)
+			ruleNum, err2 := cachedIntParam(param)
 			if err1 != nil || err2 != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if valNum <= ruleNum {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -334,15 +443,15 @@ var Rules = map[string]Rule{
 		Name: "elt",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[elt]错误")
+				return newSystemError("验证规则[elt]错误")
 			}
 			valNum, err1 := strconv.Atoi(fmt.Sprintf("%v", value))
-			ruleNum, err2 := strconv.Atoi(param)
+			ruleNum, err2 := cachedIntParam(param)
 			if err1 != nil || err2 != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if valNum > ruleNum {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -351,15 +460,15 @@ var Rules = map[string]Rule{
 		Name: "lt",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if param == "" {
-				return errors.New("验证规则[lt]错误")
+				return newSystemError("验证规则[lt]错误")
 			}
 			valNum, err1 := strconv.Atoi(fmt.Sprintf("%v", value))
-			ruleNum, err2 := strconv.Atoi(param)
+			ruleNum, err2 := cachedIntParam(param)
 			if err1 != nil || err2 != nil {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			if valNum >= ruleNum {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -368,7 +477,7 @@ var Rules = map[string]Rule{
 		Name: "array",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if _, ok := isSlice(value); !ok {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -378,17 +487,20 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if len(arr) == 0 {
-				return errors.New(title + "不能为空")
+				return ruleError("required", title)
 			}
 			if param == "" {
-				return errors.New("验证规则[arrayIn]错误")
+				return newSystemError("验证规则[arrayIn]错误")
+			}
+			ruleArr, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			ruleArr := strings.Split(param, ",")
 			if !arrayIn(arr, ruleArr) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -398,17 +510,20 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if len(arr) == 0 {
 				return nil
 			}
 			if param == "" || !strings.Contains(param, ",") {
-				return errors.New("验证规则[arrayEmptyOrIn]错误")
+				return newSystemError("验证规则[arrayEmptyOrIn]错误")
+			}
+			ruleArr, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			ruleArr := strings.Split(param, ",")
 			if !arrayIn(arr, ruleArr) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -418,13 +533,13 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if !ok || len(arr) == 0 {
-				return errors.New(title + "不能为空")
+				return ruleError("required", title)
 			}
 			if !isPositiveIntArray(arr) {
-				return errors.New(title + "需为正整数数组")
+				return ruleError("arrayPositiveInt", title)
 			}
 			return nil
 		},
@@ -434,13 +549,13 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if ok && len(arr) == 0 {
 				return nil
 			}
 			if !isPositiveIntArray(arr) {
-				return errors.New(title + "需为正整数数组")
+				return ruleError("arrayPositiveInt", title)
 			}
 			return nil
 		},
@@ -450,13 +565,13 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if !ok || len(arr) == 0 {
-				return errors.New(title + "不能为空")
+				return ruleError("required", title)
 			}
 			if !isNonnegativeIntArray(arr) {
-				return errors.New(title + "需为非负正整数数组")
+				return ruleError("arrayNonnegativeInt", title)
 			}
 			return nil
 		},
@@ -466,13 +581,13 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if ok && len(arr) == 0 {
 				return nil
 			}
 			if !isNonnegativeIntArray(arr) {
-				return errors.New(title + "需为非负正整数数组")
+				return ruleError("arrayNonnegativeInt", title)
 			}
 			return nil
 		},
@@ -481,22 +596,25 @@ var Rules = map[string]Rule{
 		Name: "mapHas",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isMap(value) {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			m, ok := value.(map[string]interface{})
 			if !ok || len(m) == 0 {
-				return errors.New(title + "不能为空")
+				return ruleError("required", title)
 			}
 			if param == "" || !strings.Contains(param, ",") {
-				return errors.New("验证规则[mapHas]错误")
+				return newSystemError("验证规则[mapHas]错误")
+			}
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			rule := strings.Split(param, ",")
 			valueKeys := make([]string, 0, len(m))
 			for k := range m {
 				valueKeys = append(valueKeys, k)
 			}
 			if len(arrayDiff(valueKeys, rule)) > 0 {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -505,22 +623,25 @@ var Rules = map[string]Rule{
 		Name: "mapEmptyOrHas",
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			if !isMap(value) {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			m, ok := value.(map[string]interface{})
 			if ok && len(m) == 0 {
 				return nil
 			}
 			if param == "" || !strings.Contains(param, ",") {
-				return errors.New("验证规则[mapEmptyOrHas]错误")
+				return newSystemError("验证规则[mapEmptyOrHas]错误")
+			}
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			rule := strings.Split(param, ",")
 			valueKeys := make([]string, 0, len(m))
 			for k := range m {
 				valueKeys = append(valueKeys, k)
 			}
 			if len(arrayDiff(valueKeys, rule)) > 0 {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -530,29 +651,32 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if len(arr) == 0 {
-				return errors.New(title + "不能为空")
+				return ruleError("required", title)
 			}
 			if param == "" {
-				return errors.New("验证规则[arrayItemHas]错误")
+				return newSystemError("验证规则[arrayItemHas]错误")
+			}
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			rule := strings.Split(param, ",")
 			for i, vv := range arr {
 				if !isMap(vv) {
-					return fmt.Errorf("%s[%d]格式错误", title, i)
+					return ruleError("item.format", title, "index", i)
 				}
 				m, ok := vv.(map[string]interface{})
 				if !ok || len(m) == 0 {
-					return fmt.Errorf("%s[%d]不能为空", title, i)
+					return ruleError("item.required", title, "index", i)
 				}
 				valueKeys := make([]string, 0, len(m))
 				for k := range m {
 					valueKeys = append(valueKeys, k)
 				}
 				if len(arrayDiff(valueKeys, rule)) > 0 {
-					return fmt.Errorf("%s[%d]错误", title, i)
+					return ruleError("item.invalid", title, "index", i)
 				}
 			}
 			return nil
@@ -563,29 +687,32 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			if len(arr) == 0 {
 				return nil
 			}
 			if param == "" {
-				return errors.New("验证规则[arrayEmptyOrItemHas]错误")
+				return newSystemError("验证规则[arrayEmptyOrItemHas]错误")
+			}
+			rule, err := cachedParams(param)
+			if err != nil {
+				return err
 			}
-			rule := strings.Split(param, ",")
 			for i, vv := range arr {
 				if !isMap(vv) {
-					return fmt.Errorf("%s[%d]格式错误", title, i)
+					return ruleError("item.format", title, "index", i)
 				}
 				m, ok := vv.(map[string]interface{})
 				if !ok || len(m) == 0 {
-					return fmt.Errorf("%s[%d]不能为空", title, i)
+					return ruleError("item.required", title, "index", i)
 				}
 				valueKeys := make([]string, 0, len(m))
 				for k := range m {
 					valueKeys = append(valueKeys, k)
 				}
 				if len(arrayDiff(valueKeys, rule)) > 0 {
-					return fmt.Errorf("%s[%d]错误", title, i)
+					return ruleError("item.invalid", title, "index", i)
 				}
 			}
 			return nil
@@ -596,7 +723,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !mobileRegex.MatchString(str) {
-				return errors.New(title + "需为11位有效手机格式")
+				return ruleError("mobile", title)
 			}
 			return nil
 		},
@@ -606,7 +733,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !emailRegex.MatchString(str) {
-				return errors.New(title + "需为有效邮箱格式")
+				return ruleError("email", title)
 			}
 			return nil
 		},
@@ -616,7 +743,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !chsRegex.MatchString(str) {
-				return errors.New(title + "只能是汉字")
+				return ruleError("chs", title)
 			}
 			return nil
 		},
@@ -626,7 +753,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !chsAlphaNumRegex.MatchString(str) {
-				return errors.New(title + "只能是汉字/字母/数字")
+				return ruleError("chsAlphaNum", title)
 			}
 			return nil
 		},
@@ -636,7 +763,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !chsDashRegex.MatchString(str) {
-				return errors.New(title + "只能是汉字/字母/数字/下划线_/破折号-")
+				return ruleError("chsDash", title)
 			}
 			return nil
 		},
@@ -646,7 +773,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !chsDashSpaceRegex.MatchString(str) {
-				return errors.New(title + "只能是汉字、字母、数字、下划线_、短横线-及空格组合")
+				return ruleError("chsDashSpace", title)
 			}
 			return nil
 		},
@@ -656,7 +783,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !chsDashCharRegex.MatchString(str) {
-				return errors.New(title + "只能是汉字、字母、数字、下划线_、短横线-及中文符号组合")
+				return ruleError("chsDashChar", title)
 			}
 			return nil
 		},
@@ -666,7 +793,7 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok || !alphaNumRegex.MatchString(str) {
-				return errors.New(title + "只能是字母/数字")
+				return ruleError("alphaNum", title)
 			}
 			return nil
 		},
@@ -676,10 +803,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !alphaDashRegex.MatchString(str) {
-				return errors.New(title + "只能是字母/数字/下划线_/短横线-")
+				return ruleError("alphaDash", title)
 			}
 			return nil
 		},
@@ -689,10 +816,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !hexColorRegex.MatchString(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -702,10 +829,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !isDate(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -715,10 +842,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !isDatetime(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -728,10 +855,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !isYear(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -741,10 +868,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !isYearMonth(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -754,10 +881,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !isMonth(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -767,10 +894,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !isTime(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -786,10 +913,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !commaIntervalChsAlphaNumRegex.MatchString(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -799,10 +926,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !commaIntervalPositiveIntRegex.MatchString(str) {
-				return errors.New(title + "错误")
+				return ruleError("invalid", title)
 			}
 			return nil
 		},
@@ -812,10 +939,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !urlRegex.MatchString(str) {
-				return errors.New(title + "地址格式错误")
+				return ruleError("url", title)
 			}
 			return nil
 		},
@@ -825,15 +952,15 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			arr, ok := isSlice(value)
 			if !ok {
-				return errors.New(title + "类型错误")
+				return ruleError("type", title)
 			}
 			for i, v := range arr {
 				str, ok := v.(string)
 				if !ok {
-					return fmt.Errorf("%s第%d个地址格式错误", title, i+1)
+					return ruleError("urls.item", title, "position", i+1)
 				}
 				if !urlRegex.MatchString(str) {
-					return fmt.Errorf("%s第%d个地址格式错误", title, i+1)
+					return ruleError("urls.item", title, "position", i+1)
 				}
 			}
 			return nil
@@ -844,10 +971,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !ipRegex.MatchString(str) {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			return nil
 		},
@@ -857,10 +984,10 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			if !uriRegex.MatchString(str) {
-				return errors.New(title + "地址格式错误")
+				return ruleError("url", title)
 			}
 			return nil
 		},
@@ -870,14 +997,96 @@ var Rules = map[string]Rule{
 		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
 			str, ok := value.(string)
 			if !ok {
-				return errors.New(title + "格式错误")
+				return ruleError("format", title)
 			}
 			var js interface{}
 			if json.Unmarshal([]byte(str), &js) != nil {
-				return errors.New(title + "需为json类型字符串")
+				return ruleError("json", title)
 			}
 			js = nil
 			return nil
 		},
 	},
+	"regex": {
+		Name: "regex",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			re, err := cachedRegexp("regex", param)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(fmt.Sprintf("%v", value)) {
+				return ruleError("format", title)
+			}
+			return nil
+		},
+	},
+	"notRegex": {
+		Name: "notRegex",
+		Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+			re, err := cachedRegexp("notRegex", param)
+			if err != nil {
+				return err
+			}
+			if re.MatchString(fmt.Sprintf("%v", value)) {
+				return ruleError("format", title)
+			}
+			return nil
+		},
+	},
+	"confirm": {
+		Name: "confirm",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			if param == "" {
+				return newSystemError("验证规则[confirm]错误")
+			}
+			fieldValue, _ := getValueByPath(datas, param)
+			if fmt.Sprintf("%v", value) != fmt.Sprintf("%v", fieldValue) {
+				return ruleError("confirm", title, "other", validatorFieldTitle(validator, param))
+			}
+			return nil
+		},
+	},
+	"different": {
+		Name: "different",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			if param == "" {
+				return newSystemError("验证规则[different]错误")
+			}
+			fieldValue, _ := getValueByPath(datas, param)
+			if !isEmpty(fieldValue) && fmt.Sprintf("%v", value) == fmt.Sprintf("%v", fieldValue) {
+				return ruleError("different", title, "other", validatorFieldTitle(validator, param))
+			}
+			return nil
+		},
+	},
+	"eqField": {
+		Name: "eqField",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			return compareField(validator, value, param, datas, title, "eqField")
+		},
+	},
+	"gtField": {
+		Name: "gtField",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			return compareField(validator, value, param, datas, title, "gtField")
+		},
+	},
+	"gteField": {
+		Name: "gteField",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			return compareField(validator, value, param, datas, title, "gteField")
+		},
+	},
+	"ltField": {
+		Name: "ltField",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			return compareField(validator, value, param, datas, title, "ltField")
+		},
+	},
+	"lteField": {
+		Name: "lteField",
+		ValidatorFun: func(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string) error {
+			return compareField(validator, value, param, datas, title, "lteField")
+		},
+	},
 }
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// 验证器定义，根据验证器类型创建，创建时解析验证规则、提示信息、标题、验证场景等，之后不可变，可在多个goroutine中并发验证
+// 每次验证创建验证器类型的新实例（零值），使用解析后的定义初始化（不再调用InitValidator及Define*方法），验证结果通过*Result返回，不修改共享的状态
+type Schema struct {
+	typ      reflect.Type           // 验证器结构体类型
+	rules    map[string]interface{} // 验证规则（含结构体标签定义的）
+	messages map[string]string      // 验证提示信息（含结构体标签定义的）
+	titles   map[string]string      // 验证字段标题（含结构体标签定义的）
+	scenes   map[string][]string    // 验证场景
+	order    []string               // 验证字段顺序
+	filters  map[string]string      // 验证前的数据过滤器
+	defaults map[string]interface{} // 字段默认值
+	registry *Registry              // 验证器的局部验证规则
+}
+
+// 嵌入validate.Validator的验证器
+type validatorBase interface {
+	validatorBase() *Validator
+}
+
+// 根据验证器类型创建验证器定义
+// prototype 验证器实例指针，如：&UserLogin{}，仅使用其类型
+func NewSchema(prototype ValidatorInterface) (schema *Schema, err error) {
+	t := reflect.TypeOf(prototype)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		err = newSystemError("请传入验证器实例指针值！")
+		return
+	}
+	// 初始化验证器类型的新实例，解析其定义
+	instance := reflect.New(t.Elem()).Interface().(ValidatorInterface)
+	base, ok := instance.(validatorBase)
+	if !ok {
+		err = newSystemError(fmt.Sprintf("验证器%v需嵌入validate.Validator", t))
+		return
+	}
+	instance.InitValidator(instance)
+	v := base.validatorBase()
+	// 检查验证器定义（如标签、验证场景定义错误）
+	if err = v.GetSystemError(); err != nil {
+		return
+	}
+	schema = &Schema{
+		typ:      t.Elem(),
+		rules:    v.Rules,
+		messages: v.Messages,
+		titles:   v.Titles,
+		scenes:   v.Scenes,
+		order:    v.Order,
+		filters:  v.Filters,
+		defaults: v.Defaults,
+		registry: v.registry,
+	}
+	return
+}
+
+// 根据验证器类型创建验证器定义，定义错误时panic（用于包级变量初始化）
+func MustNewSchema(prototype ValidatorInterface) *Schema {
+	schema, err := NewSchema(prototype)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// 创建验证器新实例（已使用验证器定义初始化）
+func (s *Schema) New() ValidatorInterface {
+	instance := reflect.New(s.typ).Interface().(ValidatorInterface)
+	instance.(validatorBase).validatorBase().initBySchema(instance, s)
+	return instance
+}
+
+// 使用验证器定义初始化验证器实例，解析后的定义由各实例共用（只读，修改时会复制）
+func (v *Validator) initBySchema(validator ValidatorInterface, s *Schema) {
+	v.validatorInstance = validator
+	v.validatorInstancePtr = reflect.ValueOf(validator)
+	v.validatorInstanceElem = v.validatorInstancePtr.Elem()
+	v.plan = planOf(s.typ)
+	v.setDatasByJsonTag()
+	v.Rules = s.rules
+	v.Messages = s.messages
+	v.Titles = s.titles
+	v.Scenes = s.scenes
+	v.Order = s.order
+	v.Filters = s.filters
+	v.Defaults = s.defaults
+	v.registry = s.registry
+	v.sharedRegistry = s.registry != nil
+}
+
+// 验证数据
+// scene 验证场景，为空时验证全部规则
+// datas 验证数据（不会被修改），值会同步到新实例对应的json标签属性上
+func (s *Schema) Validate(scene string, datas map[string]interface{}, opts ...CheckOption) *Result {
+	return s.ValidateContext(context.Background(), scene, datas, opts...)
+}
+
+// 验证数据，上下文取消、超时时中止验证
+func (s *Schema) ValidateContext(ctx context.Context, scene string, datas map[string]interface{}, opts ...CheckOption) *Result {
+	start := time.Now()
+	instance := s.New()
+	opts = applyCoerceOption(instance, opts)
+	// 复制数据，验证过程中处理数据不影响传入的数据
+	callDatas := make(map[string]interface{}, len(datas))
+	for k, v := range datas {
+		callDatas[k] = v
+	}
+	var result *Result
+	if err := instance.SetDatas(callDatas); err != nil {
+		result = newResult(instance, scene, err, nil, nil)
+	} else {
+		result = instance.ValidateContext(ctx, scene, opts...)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
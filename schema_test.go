@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// 包级变量定义的验证场景（多个goroutine共用）
+var schemaTestScenes = map[string][]string{
+	"login": {"username", "password"},
+}
+
+// Define*方法的调用次数
+var schemaTestDefineCalls int64
+
+type schemaTestUser struct {
+	Validator
+	Username string `json:"username" validate:"required|alphaNum|max:20" title:"用户名"`
+	Password string `json:"password" validate:"required|length:6,12" title:"密码"`
+	Nickname string `json:"nickname" title:"昵称"`
+}
+
+func (u *schemaTestUser) DefineScenes() map[string][]string {
+	atomic.AddInt64(&schemaTestDefineCalls, 1)
+	return schemaTestScenes
+}
+
+func (u *schemaTestUser) DefineFilters() map[string]string {
+	return map[string]string{"nickname": "trim"}
+}
+
+func (u *schemaTestUser) DefineLocalRules() []Rule {
+	return []Rule{{Name: "notAdmin", Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+		if value == "admin" {
+			return fmt.Errorf("%s不能为admin", title)
+		}
+		return nil
+	}}}
+}
+
+func (u *schemaTestUser) DefineRules() map[string]interface{} {
+	return map[string]interface{}{"username": "required|alphaNum|max:20|notAdmin"}
+}
+
+func TestSchemaValidateConcurrent(t *testing.T) {
+	schema, err := NewSchema(&schemaTestUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defineCalls := atomic.LoadInt64(&schemaTestDefineCalls)
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			username := fmt.Sprintf("user%d", i)
+			password := "123456"
+			if i%2 == 1 {
+				password = "123"
+			}
+			if i%8 == 0 {
+				username = "admin"
+			}
+			datas := map[string]interface{}{"username": username, "password": password, "nickname": " 昵称 "}
+			result := schema.Validate("login", datas, WithBatch(true))
+			wantValid := i%2 == 0 && i%8 != 0
+			if result.Valid != wantValid {
+				t.Errorf("请求%d：Valid = %v，期望 %v（%v）", i, result.Valid, wantValid, result.Err)
+				return
+			}
+			user := result.Validator.(*schemaTestUser)
+			if user.Username != username || user.Nickname != "昵称" {
+				t.Errorf("请求%d：属性 = %q、%q", i, user.Username, user.Nickname)
+			}
+			// 传入的数据不被修改
+			if datas["nickname"] != " 昵称 " {
+				t.Errorf("请求%d：传入的数据被修改：%q", i, datas["nickname"])
+			}
+			// 修改实例的定义不影响其他实例
+			user.AppendScenes(map[string][]string{"login": {"username"}})
+			user.RegisterLocalRule(Rule{Name: fmt.Sprintf("rule%d", i), Fun: func(value interface{}, param string, datas map[string]interface{}, title string) error {
+				return nil
+			}})
+		}(i)
+	}
+	wg.Wait()
+	// 验证时不再调用Define*方法
+	if calls := atomic.LoadInt64(&schemaTestDefineCalls); calls != defineCalls {
+		t.Errorf("DefineScenes调用次数 = %d，期望 %d", calls, defineCalls)
+	}
+	if len(schemaTestScenes) != 1 || len(schemaTestScenes["login"]) != 2 {
+		t.Errorf("包级变量定义的验证场景被修改：%v", schemaTestScenes)
+	}
+}
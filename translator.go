@@ -0,0 +1,211 @@
+package validate
+
+import (
+	"strings"
+	"sync"
+)
+
+// 默认语言
+const DefaultLocale = "zh-CN"
+
+// 翻译器，管理各语言的提示信息模板
+type Translator struct {
+	mu       sync.RWMutex
+	locale   string                       // 默认语言
+	catalogs map[string]map[string]string // 提示信息模板（语言 => 提示信息键 => 模板）
+}
+
+// 创建翻译器
+// locale 默认语言，未找到指定语言的提示信息时使用默认语言的
+func NewTranslator(locale string) *Translator {
+	return &Translator{
+		locale:   locale,
+		catalogs: map[string]map[string]string{},
+	}
+}
+
+// 获取默认语言
+func (t *Translator) Locale() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.locale
+}
+
+// 设置默认语言
+func (t *Translator) SetLocale(locale string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.locale = locale
+}
+
+// 添加语言的提示信息模板（已存在的键会被覆盖）
+func (t *Translator) AddMessages(locale string, messages map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	catalog, ok := t.catalogs[locale]
+	if !ok {
+		catalog = map[string]string{}
+		t.catalogs[locale] = catalog
+	}
+	for k, v := range messages {
+		catalog[k] = v
+	}
+}
+
+// 获取提示信息模板
+// 依次查找：指定语言（如en-US）、指定语言的基础语言（如en）、默认语言
+func (t *Translator) Translate(locale string, key string) (message string, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, l := range localeCandidates(locale, t.locale) {
+		if message, ok = t.catalogs[l][key]; ok {
+			return
+		}
+	}
+	return
+}
+
+// 获取语言的查找顺序
+func localeCandidates(locale string, defaultLocale string) []string {
+	candidates := []string{}
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if index := strings.IndexAny(locale, "-_"); index != -1 {
+			candidates = append(candidates, locale[:index])
+		}
+	}
+	return append(candidates, defaultLocale)
+}
+
+// 从各语言的提示信息模板中获取指定语言的（如验证规则定义的翻译）
+func pickLocaleMessage(messages map[string]string, locale string) (message string, ok bool) {
+	if len(messages) == 0 {
+		return
+	}
+	for _, l := range localeCandidates(locale, DefaultTranslator.Locale()) {
+		if message, ok = messages[l]; ok {
+			return
+		}
+	}
+	return
+}
+
+// 默认翻译器
+var DefaultTranslator = NewTranslator(DefaultLocale)
+
+// 设置默认语言
+func SetDefaultLocale(locale string) {
+	DefaultTranslator.SetLocale(locale)
+}
+
+// 注册语言的提示信息模板（如新增语言、覆盖内置提示信息、注册自定义规则RuleError的提示信息）
+func RegisterMessages(locale string, messages map[string]string) {
+	DefaultTranslator.AddMessages(locale, messages)
+}
+
+// 翻译提示信息，未找到时返回空
+func translate(locale string, key string) string {
+	message, _ := DefaultTranslator.Translate(locale, key)
+	return message
+}
+
+func init() {
+	RegisterMessages("zh-CN", zhCNMessages)
+	RegisterMessages("en", enMessages)
+}
+
+// 内置简体中文提示信息
+var zhCNMessages = map[string]string{
+	"title.index":         "{title}第{index}项",
+	"title.key":           "{title}[{key}]",
+	"required":            "{title}不能为空",
+	"invalid":             "{title}错误",
+	"format":              "{title}格式错误",
+	"type":                "{title}类型错误",
+	"string":              "{title}需为字符串形式",
+	"number":              "{title}需由数字组成",
+	"integer":             "{title}需为整数",
+	"positiveInt":         "{title}需为正整数",
+	"nonnegativeInt":      "{title}需为非负整数",
+	"float":               "{title}需为浮点数",
+	"boolean":             "{title}需为布尔值",
+	"length":              "{title}限制长度{length}",
+	"length.range":        "{title}限制长度区间{min}-{max}",
+	"min":                 "{title}限制最小长度{min}",
+	"max":                 "{title}限制最大长度{max}",
+	"arrayPositiveInt":    "{title}需为正整数数组",
+	"arrayNonnegativeInt": "{title}需为非负正整数数组",
+	"item.format":         "{title}[{index}]格式错误",
+	"item.required":       "{title}[{index}]不能为空",
+	"item.invalid":        "{title}[{index}]错误",
+	"mobile":              "{title}需为11位有效手机格式",
+	"email":               "{title}需为有效邮箱格式",
+	"chs":                 "{title}只能是汉字",
+	"chsAlphaNum":         "{title}只能是汉字/字母/数字",
+	"chsDash":             "{title}只能是汉字/字母/数字/下划线_/破折号-",
+	"chsDashSpace":        "{title}只能是汉字、字母、数字、下划线_、短横线-及空格组合",
+	"chsDashChar":         "{title}只能是汉字、字母、数字、下划线_、短横线-及中文符号组合",
+	"alphaNum":            "{title}只能是字母/数字",
+	"alphaDash":           "{title}只能是字母/数字/下划线_/短横线-",
+	"url":                 "{title}地址格式错误",
+	"urls.item":           "{title}第{position}个地址格式错误",
+	"json":                "{title}需为json类型字符串",
+	"timeRange.start":     "{title}开始时间错误",
+	"timeRange.end":       "{title}结束时间错误",
+	"timeRange.order":     "{title}开始时间不能大于结束时间",
+	"confirm":             "{title}与{other}不一致",
+	"different":           "{title}不能与{other}相同",
+	"eqField":             "{title}需等于{other}",
+	"gtField":             "{title}需大于{other}",
+	"gteField":            "{title}需大于等于{other}",
+	"ltField":             "{title}需小于{other}",
+	"lteField":            "{title}需小于等于{other}",
+}
+
+// 内置英文提示信息
+var enMessages = map[string]string{
+	"title.index":         "{title} item {index}",
+	"title.key":           "{title}[{key}]",
+	"required":            "{title} is required",
+	"invalid":             "{title} is invalid",
+	"format":              "{title} has an invalid format",
+	"type":                "{title} has an invalid type",
+	"string":              "{title} must be a string",
+	"number":              "{title} must be a number",
+	"integer":             "{title} must be an integer",
+	"positiveInt":         "{title} must be a positive integer",
+	"nonnegativeInt":      "{title} must be a non-negative integer",
+	"float":               "{title} must be a float",
+	"boolean":             "{title} must be a boolean",
+	"length":              "{title} must be {length} characters long",
+	"length.range":        "{title} must be between {min} and {max} characters long",
+	"min":                 "{title} must be at least {min} characters long",
+	"max":                 "{title} may not be longer than {max} characters",
+	"arrayPositiveInt":    "{title} must be an array of positive integers",
+	"arrayNonnegativeInt": "{title} must be an array of non-negative integers",
+	"item.format":         "{title}[{index}] has an invalid format",
+	"item.required":       "{title}[{index}] is required",
+	"item.invalid":        "{title}[{index}] is invalid",
+	"mobile":              "{title} must be a valid 11-digit mobile number",
+	"email":               "{title} must be a valid email address",
+	"chs":                 "{title} may only contain Chinese characters",
+	"chsAlphaNum":         "{title} may only contain Chinese characters, letters and numbers",
+	"chsDash":             "{title} may only contain Chinese characters, letters, numbers, underscores and dashes",
+	"chsDashSpace":        "{title} may only contain Chinese characters, letters, numbers, underscores, dashes and spaces",
+	"chsDashChar":         "{title} may only contain Chinese characters, letters, numbers, underscores, dashes and Chinese punctuation",
+	"alphaNum":            "{title} may only contain letters and numbers",
+	"alphaDash":           "{title} may only contain letters, numbers, underscores and dashes",
+	"url":                 "{title} must be a valid URL",
+	"urls.item":           "{title} item {position} must be a valid URL",
+	"json":                "{title} must be a valid JSON string",
+	"timeRange.start":     "{title} has an invalid start time",
+	"timeRange.end":       "{title} has an invalid end time",
+	"timeRange.order":     "{title} start time may not be after the end time",
+	"confirm":             "{title} does not match {other}",
+	"different":           "{title} must be different from {other}",
+	"eqField":             "{title} must be equal to {other}",
+	"gtField":             "{title} must be greater than {other}",
+	"gteField":            "{title} must be greater than or equal to {other}",
+	"ltField":             "{title} must be less than {other}",
+	"lteField":            "{title} must be less than or equal to {other}",
+}
@@ -1,11 +1,11 @@
 package validate
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -71,6 +71,13 @@ func isEmpty(value interface{}) bool {
 		// 结构体类型，检查每个字段是否为空
 		for i := 0; i < v.NumField(); i++ {
 			field := v.Field(i)
+			// 未导出的属性（如time.Time的属性）无法获取值，判断是否为零值
+			if !field.CanInterface() {
+				if !field.IsZero() {
+					return false
+				}
+				continue
+			}
 			if !isEmpty(field.Interface()) {
 				return false
 			}
@@ -315,15 +322,15 @@ func ucFirst(str string) string {
 func isTimeRange(value interface{}, rule string, title string) error {
 	valueMap, ok := value.(map[string]interface{})
 	if !ok {
-		return errors.New(title + "格式错误")
+		return ruleError("format", title)
 	}
 	start, startExists := valueMap["start"].(string)
 	end, endExists := valueMap["end"].(string)
 	if !startExists && !endExists {
-		return errors.New(title + "错误")
+		return ruleError("invalid", title)
 	}
 	if rule == "" {
-		return errors.New("验证规则[timeRange]的参数数据缺失")
+		return newSystemError("验证规则[timeRange]的参数数据缺失")
 	}
 	checkFunc := map[string]func(string) bool{
 		"Date":      isDate,
@@ -335,19 +342,19 @@ func isTimeRange(value interface{}, rule string, title string) error {
 	}
 	check, exists := checkFunc[ucFirst(rule)]
 	if !exists {
-		return fmt.Errorf("验证规则[timeRange:%s]错误", rule)
+		return newSystemError(fmt.Sprintf("验证规则[timeRange:%s]错误", rule))
 	}
 	if start != "" && !check(start) {
-		return errors.New(title + "开始时间错误")
+		return ruleError("timeRange.start", title)
 	}
 	if end != "" && !check(end) {
-		return errors.New(title + "结束时间错误")
+		return ruleError("timeRange.end", title)
 	}
 	if start != "" && end != "" {
 		startNum, _ := strconv.Atoi(strings.ReplaceAll(start, "[^0-9]", ""))
 		endNum, _ := strconv.Atoi(strings.ReplaceAll(end, "[^0-9]", ""))
 		if startNum > endNum {
-			return errors.New(title + "开始时间不能大于结束时间")
+			return ruleError("timeRange.order", title)
 		}
 	}
 	return nil
@@ -379,3 +386,207 @@ func isSlice(value interface{}) (newSlice []interface{}, ok bool) {
 	}
 	return
 }
+
+// 获取排序后的map键
+// orders 依次按各顺序排列，多级路径（如address.city）未在顺序中时跟随其第一级字段排列，
+// 其余键按名称排序并排在最后
+func sortKeys(m map[string]interface{}, orders ...[]string) []string {
+	positions := map[string]int{}
+	for _, order := range orders {
+		for _, key := range order {
+			if _, ok := positions[key]; !ok {
+				positions[key] = len(positions)
+			}
+		}
+	}
+	// 获取键的排序位置
+	position := func(key string) (pos int, child bool) {
+		if pos, ok := positions[key]; ok {
+			return pos, false
+		}
+		if dotIndex := strings.Index(key, "."); dotIndex != -1 {
+			if pos, ok := positions[key[:dotIndex]]; ok {
+				return pos, true
+			}
+		}
+		return len(positions), false
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		posI, childI := position(keys[i])
+		posJ, childJ := position(keys[j])
+		if posI != posJ {
+			return posI < posJ
+		}
+		if childI != childJ {
+			return !childI
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// 获取字段验证规则的自定义提示信息
+// keys 依次查找的字段（如完整路径items.0.sku、定义路径items.*.sku）
+// 依次查找的键为：字段.规则名称、字段.*（字段的所有规则）、*.规则名称（所有字段的规则）、规则名称
+func fieldMessage(messages map[string]string, ruleName string, keys ...string) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	for _, key := range keys {
+		if message := messages[key+"."+ruleName]; message != "" {
+			return message
+		}
+	}
+	for _, key := range keys {
+		if message := messages[key+".*"]; message != "" {
+			return message
+		}
+	}
+	if message := messages["*."+ruleName]; message != "" {
+		return message
+	}
+	return messages[ruleName]
+}
+
+// 判断字符串是否在数组中
+func inArray(value string, arr []string) bool {
+	for _, v := range arr {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// 获取存在且不为空的字段数量
+func presentFieldNum(datas map[string]interface{}, fields []string) int {
+	num := 0
+	for _, field := range fields {
+		if value, exists := getValueByPath(datas, field); exists && !isEmpty(value) {
+			num++
+		}
+	}
+	return num
+}
+
+// 比较的日期时间格式
+var compareTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"15:04:05",
+	time.RFC3339,
+}
+
+// 转换为时间
+func toTime(value interface{}) (t time.Time, ok bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return
+		}
+		return *v, true
+	case string:
+		for _, layout := range compareTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return
+}
+
+// 比较两个值，a小于、等于、大于b时分别返回-1、0、1
+// 均为数字（或数字字符串）时按数值比较，均为日期时间（或日期时间字符串）时按时间比较，否则按字符串比较
+func compareValues(a interface{}, b interface{}) int {
+	if isNumeric(a) && isNumeric(b) {
+		numA, _ := strconv.ParseFloat(fmt.Sprintf("%v", a), 64)
+		numB, _ := strconv.ParseFloat(fmt.Sprintf("%v", b), 64)
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		}
+		return 0
+	}
+	if timeA, ok := toTime(a); ok {
+		if timeB, ok := toTime(b); ok {
+			switch {
+			case timeA.Before(timeB):
+				return -1
+			case timeA.After(timeB):
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+// 获取验证器中字段的标题，未定义时为字段名称
+func validatorFieldTitle(validator ValidatorInterface, field string) string {
+	if validator == nil {
+		return field
+	}
+	titles, _ := validator.GetTitles()
+	return fieldTitle(titles, field, field, "")
+}
+
+// 与其他字段比较（其他字段为空时不比较）
+func compareField(validator ValidatorInterface, value interface{}, param string, datas map[string]interface{}, title string, ruleName string) error {
+	if param == "" {
+		return newSystemError(fmt.Sprintf("验证规则[%s]错误", ruleName))
+	}
+	fieldValue, exists := getValueByPath(datas, param)
+	if !exists || isEmpty(fieldValue) {
+		return nil
+	}
+	otherTitle := validatorFieldTitle(validator, param)
+	result := compareValues(value, fieldValue)
+	switch ruleName {
+	case "eqField":
+		if result != 0 {
+			return ruleError("eqField", title, "other", otherTitle)
+		}
+	case "gtField":
+		if result <= 0 {
+			return ruleError("gtField", title, "other", otherTitle)
+		}
+	case "gteField":
+		if result < 0 {
+			return ruleError("gteField", title, "other", otherTitle)
+		}
+	case "ltField":
+		if result >= 0 {
+			return ruleError("ltField", title, "other", otherTitle)
+		}
+	case "lteField":
+		if result > 0 {
+			return ruleError("lteField", title, "other", otherTitle)
+		}
+	}
+	return nil
+}
+
+// 解析验证规则参数中的正则表达式
+func paramRegexp(ruleName string, param string) (*regexp.Regexp, error) {
+	pattern, err := ParseParam(param)
+	if err != nil {
+		return nil, err
+	}
+	if pattern == "" {
+		return nil, newSystemError(fmt.Sprintf("验证规则[%s]参数需为正则表达式", ruleName))
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, newSystemError(fmt.Sprintf("验证规则[%s]正则表达式错误：%s", ruleName, err.Error()))
+	}
+	return re, nil
+}
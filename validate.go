@@ -1,49 +1,92 @@
 package validate
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strings"
 )
 
 // 校验单个变量
 // data: 待验证的数据
-// rule: 规则
+// rule: 规则，可包含过滤器（验证前执行，如：trim|required|email）
 // title: 标题
-// messages: 自定义错误信息
-func CheckVar(data interface{}, rule string, title string, messages map[string]string) (err error) {
-	ruleSlice := strings.Split(rule, "|")
-	// 判断数据是否为空
-	if ruleSlice[0] != "required" && isEmpty(data) {
+// messages: 自定义错误信息，键为规则名称，支持占位符，如：{title}长度需为{param.0}-{param.1}
+// opts: 验证选项，如：WithLocale("en")
+func CheckVar(data interface{}, rule string, title string, messages map[string]string, opts ...CheckOption) (err error) {
+	return CheckVarContext(context.Background(), data, rule, title, messages, opts...)
+}
+
+// 校验单个变量，上下文取消、超时时中止验证并返回上下文错误
+// 上下文传递给验证规则的CtxFun
+func CheckVarContext(ctx context.Context, data interface{}, rule string, title string, messages map[string]string, opts ...CheckOption) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	checkOpts := newCheckOptions(opts)
+	if checkOpts.err != nil {
+		err = newSystemError(checkOpts.err.Error())
 		return
 	}
-	for _, ruleItemStr := range ruleSlice {
-		if ruleItemStr == "" {
+	if checkOpts.coerce != nil {
+		err = newSystemError("WithCoerce不可用于CheckVar")
+		return
+	}
+	scope := newRuleScope(checkOpts.registries...)
+	ruleItems, err := parseRuleStringCached(&varRuleChains, rule)
+	if err != nil {
+		return
+	}
+	// 验证前执行过滤器（与验证规则同名时作为验证规则）
+	filterNames := map[string]bool{}
+	for _, ruleItem := range ruleItems {
+		if _, ok := scope.Lookup(ruleItem.Name); ok {
+			continue
+		}
+		filter, ok := LookupFilter(ruleItem.Name)
+		if !ok {
+			continue
+		}
+		filterNames[ruleItem.Name] = true
+		filtered, filterErr := filter(data)
+		if filterErr != nil {
+			message := messages[ruleItem.Name]
+			if message == "" {
+				message = translate(checkOpts.locale, "type")
+			}
+			err = newFieldError("", title, ruleItem.Name, "", data, filterErr, message, checkOpts.locale)
+			return
+		}
+		data = filtered
+	}
+	// 判断数据是否为空
+	dataEmpty := isEmpty(data)
+	for _, ruleItem := range ruleItems {
+		ruleName, ruleParam := ruleItem.Name, ruleItem.Param
+		if filterNames[ruleName] {
+			continue
+		}
+		// 数据为空时只验证必填类规则
+		if dataEmpty && !scope.isPresenceRule(ruleName) {
 			continue
 		}
-		// 获取规则、规则参数
-		var ruleName, ruleParam string
-		colonIndex := strings.Index(ruleItemStr, ":")
-		if colonIndex == -1 {
-			ruleName = ruleItemStr
-			ruleParam = ""
-		} else {
-			ruleName = ruleItemStr[:colonIndex]
-			ruleParam = ruleItemStr[colonIndex+1:]
+		// 上下文取消、超时时中止验证
+		if err = ctx.Err(); err != nil {
+			return
 		}
 		// 判断是否为注册的规则
-		if rule, ok := Rules[ruleName]; ok {
-			err = rule.Check(data, ruleParam, nil, title)
+		if rule, ok := scope.Lookup(ruleName); ok {
+			err = rule.CheckContext(ctx, data, ruleParam, nil, title)
 			if err != nil {
-				defineMessage := messages[ruleName]
-				if defineMessage != "" {
-					err = errors.New(defineMessage)
+				// 验证规则配置错误、上下文错误
+				if errors.Is(err, ErrSystem) || isContextError(err) {
+					return
 				}
+				err = newFieldError("", title, ruleName, ruleParam, data, err, ruleMessage(messages[ruleName], rule, err, checkOpts.locale), checkOpts.locale)
 				return
 			}
 			continue
 		}
-		err = fmt.Errorf("验证规则%s未定义", ruleName)
+		err = newSystemError(fmt.Sprintf("验证规则%s未定义", ruleName))
 		return
 	}
 	return
@@ -1,9 +1,11 @@
 package validate
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -25,10 +27,24 @@ type ValidatorInterface interface {
 	GetScenes() (scenes map[string][]string, err error)
 	SetScenes(scenes map[string][]string) (err error)    // 设置验证场景
 	AppendScenes(scenes map[string][]string) (err error) // 追加验证场景
+	DefineOrder() []string
+	DefineLocalRules() []Rule // 定义验证器的局部验证规则（仅当前验证器可用，优先于全局注册的规则）
+	GetOrder() (order []string, err error)
+	SetOrder(order []string) (err error) // 设置验证字段顺序
+	DefineFilters() map[string]string    // 定义验证前的数据过滤器，如："username": "trim|lower"
+	GetFilters() (filters map[string]string, err error)
+	SetFilters(filters map[string]string) (err error) // 设置验证前的数据过滤器
+	DefineDefaults() map[string]interface{}           // 定义字段默认值
+	GetDefaults() (defaults map[string]interface{}, err error)
+	SetDefaults(defaults map[string]interface{}) (err error) // 设置字段默认值
 	GetDatas() (datas map[string]interface{}, err error)
 	SetDatas(datas map[string]interface{}) (err error)
-	Check() error
-	CheckScene(scene string) error
+	Check(opts ...CheckOption) error
+	CheckScene(scene string, opts ...CheckOption) error
+	CheckContext(ctx context.Context, opts ...CheckOption) error                    // 验证（上下文取消、超时时中止验证）
+	CheckSceneContext(ctx context.Context, scene string, opts ...CheckOption) error // 验证指定场景（上下文取消、超时时中止验证）
+	Validate(scene string, opts ...CheckOption) *Result                             // 验证并返回验证结果
+	ValidateContext(ctx context.Context, scene string, opts ...CheckOption) *Result // 验证并返回验证结果（上下文取消、超时时中止验证）
 	GetScene() (scene string, err error)
 	HandleDatas(datas map[string]interface{}, scene string) error
 }
@@ -38,15 +54,38 @@ type Validator struct {
 	Messages        map[string]string      // 验证提示信息
 	Titles          map[string]string      // 验证字段标题
 	Scenes          map[string][]string    // 验证场景
+	Order           []string               // 验证字段顺序（优先级低于场景定义顺序、结构体属性定义顺序）
+	Filters         map[string]string      // 验证前的数据过滤器
+	Defaults        map[string]interface{} // 字段默认值
 	Datas           map[string]interface{} // 验证数据
 	Scene           string                 // 当前验证场景
 	CheckRules      map[string]interface{} // 当前验证规则
+	CheckKeys       []string               // 当前验证字段（按验证顺序）
 	SystemErrPrefix string                 // 系统错误前缀
+	Batch           bool                   // 是否批量验证（验证所有字段并返回错误集合*Errors）
+	Locale          string                 // 提示信息语言（为空时使用默认语言）
+	Coerce          bool                   // 是否转换数据类型（验证数据同步到属性时转换为属性类型，转换失败时验证返回该字段的类型错误）
 	Err             error                  // 错误
 
-	validatorInstance     ValidatorInterface // 验证器实例
-	validatorInstancePtr  reflect.Value      // 验证器实例结构体指针的反射值
-	validatorInstanceElem reflect.Value      // 验证器实例结构体本身的反射值
+	batch          bool            // 当前是否批量验证
+	locale         string          // 当前提示信息语言
+	registry       *Registry       // 验证器的局部验证规则
+	sharedRegistry bool            // 局部验证规则是否与验证器定义共用
+	callRegistries []*Registry     // 当前验证使用的验证规则注册表（WithRules、WithRegistry设置）
+	rules          ruleScope       // 当前验证规则查找范围
+	ctx            context.Context // 当前验证的上下文
+
+	validatorInstance     ValidatorInterface     // 验证器实例
+	validatorInstancePtr  reflect.Value          // 验证器实例结构体指针的反射值
+	validatorInstanceElem reflect.Value          // 验证器实例结构体本身的反射值
+	plan                  *validatorPlan         // 验证器类型的验证计划（同一类型共用）
+	fieldKeys             []string               // 结构体属性json标签（按属性定义顺序）
+	tagRules              map[string]interface{} // 结构体属性validate标签定义的验证规则
+	tagMessages           map[string]string      // 结构体属性msg标签定义的验证提示信息
+	tagTitles             map[string]string      // 结构体属性title标签定义的验证字段标题
+	childFields           map[string]childField  // 子验证器属性（键为json标签）
+	coerceErrs            map[string]error       // 验证数据转换为属性类型失败的错误（键为json标签）
+	zeroKeys              map[string]bool        // 结构体属性初始化的零值数据（未传入的数据，键为json标签）
 }
 
 // 设置验证器实例
@@ -63,45 +102,100 @@ func (v *Validator) InitValidator(validator ValidatorInterface) {
 		v.validatorInstancePtr = validatorInstanceValue
 		// 验证器实例结构体本身的反射值
 		v.validatorInstanceElem = validatorInstanceValue.Elem()
+		// 验证器类型的验证计划
+		v.plan = planOf(v.validatorInstanceElem.Type())
 	}
 
+	// 设置验证数据（同时解析结构体标签定义的规则、提示信息、标题）
+	v.setDatasByJsonTag()
 	// 设置定义的属性
 	v.SetRules(v.validatorInstance.DefineRules())
 	v.SetMessages(v.validatorInstance.DefineMessages())
 	v.SetTitles(v.validatorInstance.DefineTitles())
 	v.SetScenes(v.validatorInstance.DefineScenes())
-	// 设置验证数据
-	v.setDatasByJsonTag()
+	v.SetOrder(v.validatorInstance.DefineOrder())
+	v.SetFilters(v.validatorInstance.DefineFilters())
+	v.SetDefaults(v.validatorInstance.DefineDefaults())
+	// 局部验证规则只注册一次（子验证器每次验证时会重新设置验证器实例）
+	if v.registry == nil {
+		v.RegisterLocalRules(v.validatorInstance.DefineLocalRules())
+	}
 }
 
-// 设置结构体验证数据，根据json标签
-func (v *Validator) setDatasByJsonTag() (err error) {
+// 获取嵌入的验证器（用于验证器定义初始化新实例）
+func (v *Validator) validatorBase() *Validator {
+	return v
+}
+
+// 定义验证器的局部验证规则
+func (v *Validator) DefineLocalRules() []Rule {
+	return nil
+}
+
+// 注册验证器的局部验证规则，仅当前验证器可用，优先于全局注册的规则
+func (v *Validator) RegisterLocalRule(rule Rule) (err error) {
 	err = v.GetError()
 	if err != nil {
 		return
 	}
-	datas := make(map[string]interface{})
+	if v.registry == nil {
+		v.registry = NewRegistry()
+	} else if v.sharedRegistry {
+		// 与验证器定义共用的局部规则，复制后注册
+		v.registry = v.registry.clone()
+		v.sharedRegistry = false
+	}
+	err = v.registry.Register(rule)
+	if err != nil {
+		err = v.SetSystemError(fmt.Sprintf("注册局部验证规则错误：%s", err.Error()))
+	}
+	return
+}
 
-	// 获取结构体的类型
-	typeOf := v.validatorInstanceElem.Type()
-	// 遍历结构体的所有字段
-	for i := 0; i < v.validatorInstanceElem.NumField(); i++ {
-		field := v.validatorInstanceElem.Field(i)
-		typeField := typeOf.Field(i)
-		// 获取 JSON 标签
-		jsonTag := typeField.Tag.Get("json")
-		// 解析 JSON 标签，处理可能的选项，如 omitempty
-		if commaIndex := strings.Index(jsonTag, ","); commaIndex != -1 {
-			jsonTag = jsonTag[:commaIndex]
+// 注册验证器的多个局部验证规则
+func (v *Validator) RegisterLocalRules(rules []Rule) (err error) {
+	for _, rule := range rules {
+		err = v.RegisterLocalRule(rule)
+		if err != nil {
+			return
 		}
-		// 如果 JSON 标签不为空，则使用该标签作为键
-		if jsonTag != "" {
-			datas[jsonTag] = field.Interface()
+	}
+	return
+}
+
+// 设置结构体验证数据，根据json标签
+// 同时解析属性的标签：
+// validate 验证规则，如：validate:"required|max:20"
+// title 验证字段标题，如：title:"用户名"
+// scene 子验证器属性的验证场景，如：scene:"create"
+// msg 验证提示信息，多个以“|”间隔，如：msg:"required:请输入用户名|max:用户名最多20个字符"
+// 标签定义的内容会被DefineRules、DefineTitles、DefineMessages中定义的覆盖
+func (v *Validator) setDatasByJsonTag() (err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	// 结构体标签按类型解析一次，同一类型的验证器实例共用
+	plan := v.plan
+	datas := make(map[string]interface{}, len(plan.fields))
+	zeroKeys := map[string]bool{}
+	for _, field := range plan.fields {
+		fieldValue := v.validatorInstanceElem.Field(field.Index)
+		datas[field.Key] = fieldValue.Interface()
+		// 零值的属性视为未传入的数据（可设置默认值）
+		if fieldValue.IsZero() {
+			zeroKeys[field.Key] = true
 		}
 	}
 
 	// 设置验证数据
 	v.Datas = datas
+	v.zeroKeys = zeroKeys
+	v.fieldKeys = plan.fieldKeys
+	v.tagRules = plan.tagRules
+	v.tagMessages = plan.tagMessages
+	v.tagTitles = plan.tagTitles
+	v.childFields = plan.childFields
 	return
 }
 
@@ -129,9 +223,8 @@ func (v *Validator) GetSystemError() (err error) {
 	if err == nil {
 		return
 	}
-	errMsg := err.Error()
-	errPrefix, _ := v.getSystemErrPrefix()
-	if strings.HasPrefix(errMsg, errPrefix) {
+	var sysErr *SystemError
+	if errors.As(err, &sysErr) {
 		return
 	}
 	err = nil
@@ -140,21 +233,24 @@ func (v *Validator) GetSystemError() (err error) {
 
 // 设置系统内部错误
 func (v *Validator) SetSystemError(err interface{}) error {
-	var resErrMsg string
+	sysErr := &SystemError{}
 	if _errMsg, ok := err.(string); ok {
-		resErrMsg = _errMsg
+		sysErr.Message = _errMsg
 	} else if _err, ok := err.(error); ok {
-		resErrMsg = _err.Error()
+		if !errors.As(_err, &sysErr) {
+			sysErr = &SystemError{Message: _err.Error(), Err: _err}
+		}
 	} else {
-		resErrMsg = "未知错误！"
+		sysErr.Message = "未知错误！"
 	}
 
 	// 错误前缀
-	errPrefix, _ := v.getSystemErrPrefix()
-	if !strings.HasPrefix(resErrMsg, errPrefix) {
-		resErrMsg = errPrefix + resErrMsg
+	if sysErr.Prefix == "" {
+		errPrefix, _ := v.getSystemErrPrefix()
+		sysErr.Message = strings.TrimPrefix(sysErr.Message, errPrefix)
+		sysErr.Prefix = errPrefix
 	}
-	return v.SetError(resErrMsg)
+	return v.SetError(sysErr)
 }
 
 // 设置验证器错误
@@ -162,15 +258,19 @@ func (v *Validator) SetError(err interface{}) error {
 	if _err := v.GetError(); _err != nil {
 		return _err
 	}
-	var resErrMsg string
+	var newErr error
 	if _errMsg, ok := err.(string); ok {
-		resErrMsg = _errMsg
+		newErr = &ValidationError{Message: _errMsg}
 	} else if _err, ok := err.(error); ok {
-		resErrMsg = _err.Error()
+		// 保留系统错误、验证失败错误类型，其他错误视为验证失败
+		if errors.Is(_err, ErrSystem) || errors.Is(_err, ErrValidation) {
+			newErr = _err
+		} else {
+			newErr = &ValidationError{Message: _err.Error(), Err: _err}
+		}
 	} else {
-		resErrMsg = "未知错误！"
+		newErr = &ValidationError{Message: "未知错误！"}
 	}
-	newErr := errors.New(resErrMsg)
 	v.Err = newErr
 	return newErr
 }
@@ -180,6 +280,21 @@ func (v *Validator) DefineRules() map[string]interface{} {
 	return nil
 }
 
+// 设置是否批量验证
+func (v *Validator) SetBatch(batch bool) {
+	v.Batch = batch
+}
+
+// 设置提示信息语言，如：zh-CN、en
+func (v *Validator) SetLocale(locale string) {
+	v.Locale = locale
+}
+
+// 设置是否转换数据类型（需在SetDatas前设置）
+func (v *Validator) SetCoerce(coerce bool) {
+	v.Coerce = coerce
+}
+
 // 获取验证规则
 func (v *Validator) GetRules() (rules map[string]interface{}, err error) {
 	err = v.GetError()
@@ -196,9 +311,12 @@ func (v *Validator) SetRules(rules map[string]interface{}) (err error) {
 	if err != nil {
 		return
 	}
-	allRules := v.validatorInstance.DefineRules()
-	if allRules == nil {
-		allRules = map[string]interface{}{}
+	allRules := map[string]interface{}{}
+	for k, v := range v.tagRules {
+		allRules[k] = v
+	}
+	for k, v := range v.validatorInstance.DefineRules() {
+		allRules[k] = v
 	}
 	for k, v := range rules {
 		allRules[k] = v
@@ -221,13 +339,15 @@ func (v *Validator) AppendRules(rules map[string]interface{}) (err error) {
 	if err != nil {
 		return
 	}
-	if currRules == nil {
-		currRules = map[string]interface{}{}
+	// 复制后追加，不修改原map（可能与其他验证器实例共用，如验证器定义创建的实例）
+	allRules := make(map[string]interface{}, len(currRules)+len(rules))
+	for k, v := range currRules {
+		allRules[k] = v
 	}
 	for k, v := range rules {
-		currRules[k] = v
+		allRules[k] = v
 	}
-	v.Rules = currRules
+	v.Rules = allRules
 	return
 }
 
@@ -252,9 +372,12 @@ func (v *Validator) SetMessages(messages map[string]string) (err error) {
 	if err != nil {
 		return
 	}
-	allMessages := v.validatorInstance.DefineMessages()
-	if allMessages == nil {
-		allMessages = map[string]string{}
+	allMessages := map[string]string{}
+	for k, v := range v.tagMessages {
+		allMessages[k] = v
+	}
+	for k, v := range v.validatorInstance.DefineMessages() {
+		allMessages[k] = v
 	}
 	for k, v := range messages {
 		allMessages[k] = v
@@ -277,13 +400,15 @@ func (v *Validator) AppendMessages(messages map[string]string) (err error) {
 	if err != nil {
 		return
 	}
-	if currMessages == nil {
-		currMessages = map[string]string{}
+	// 复制后追加，不修改原map（可能与其他验证器实例共用，如验证器定义创建的实例）
+	allMessages := make(map[string]string, len(currMessages)+len(messages))
+	for k, v := range currMessages {
+		allMessages[k] = v
 	}
 	for k, v := range messages {
-		currMessages[k] = v
+		allMessages[k] = v
 	}
-	v.Messages = currMessages
+	v.Messages = allMessages
 	return
 }
 
@@ -308,9 +433,12 @@ func (v *Validator) SetTitles(titles map[string]string) (err error) {
 	if err != nil {
 		return
 	}
-	allTitles := v.validatorInstance.DefineTitles()
-	if allTitles == nil {
-		allTitles = map[string]string{}
+	allTitles := map[string]string{}
+	for k, v := range v.tagTitles {
+		allTitles[k] = v
+	}
+	for k, v := range v.validatorInstance.DefineTitles() {
+		allTitles[k] = v
 	}
 	for k, v := range titles {
 		allTitles[k] = v
@@ -333,13 +461,15 @@ func (v *Validator) AppendTitles(titles map[string]string) (err error) {
 	if err != nil {
 		return
 	}
-	if currTitles == nil {
-		currTitles = map[string]string{}
+	// 复制后追加，不修改原map（可能与其他验证器实例共用，如验证器定义创建的实例）
+	allTitles := make(map[string]string, len(currTitles)+len(titles))
+	for k, v := range currTitles {
+		allTitles[k] = v
 	}
 	for k, v := range titles {
-		currTitles[k] = v
+		allTitles[k] = v
 	}
-	v.Titles = currTitles
+	v.Titles = allTitles
 	return
 }
 
@@ -374,9 +504,10 @@ func (v *Validator) SetScenes(scenes map[string][]string) (err error) {
 	if err != nil {
 		return
 	}
-	allScenes := v.validatorInstance.DefineScenes()
-	if allScenes == nil {
-		allScenes = map[string][]string{}
+	// 复制定义的验证场景，避免修改DefineScenes返回的map（如包级变量，多个goroutine共用）
+	allScenes := map[string][]string{}
+	for k, v := range v.validatorInstance.DefineScenes() {
+		allScenes[k] = v
 	}
 	for k, v := range scenes {
 		allScenes[k] = v
@@ -399,13 +530,96 @@ func (v *Validator) AppendScenes(scenes map[string][]string) (err error) {
 	if err != nil {
 		return
 	}
-	if currScenes == nil {
-		currScenes = map[string][]string{}
+	// 复制后追加，不修改原map（可能与其他验证器实例共用，如验证器定义创建的实例）
+	allScenes := make(map[string][]string, len(currScenes)+len(scenes))
+	for k, v := range currScenes {
+		allScenes[k] = v
 	}
 	for k, v := range scenes {
-		currScenes[k] = v
+		allScenes[k] = v
 	}
-	v.Scenes = currScenes
+	v.Scenes = allScenes
+	return
+}
+
+// 定义验证字段顺序
+func (v *Validator) DefineOrder() []string {
+	return nil
+}
+
+// 获取验证字段顺序
+func (v *Validator) GetOrder() (order []string, err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	order = v.Order
+	return
+}
+
+// 设置验证字段顺序
+// 验证顺序依次为：场景定义的字段顺序、结构体属性定义顺序、设置的验证字段顺序，其余字段按名称排序
+func (v *Validator) SetOrder(order []string) (err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	v.Order = order
+	return
+}
+
+// 定义验证前的数据过滤器
+func (v *Validator) DefineFilters() map[string]string {
+	return nil
+}
+
+// 获取验证前的数据过滤器
+func (v *Validator) GetFilters() (filters map[string]string, err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	filters = v.Filters
+	return
+}
+
+// 设置验证前的数据过滤器，键为字段（支持多级路径，如：items.*.sku），值为过滤器，多个以“|”间隔，如：trim|lower
+// 验证前依次执行过滤器，过滤后的值设置到验证数据并同步到对应的json标签属性上（不论字段是否在当前验证场景中）
+func (v *Validator) SetFilters(filters map[string]string) (err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	v.Filters = filters
+	return
+}
+
+// 定义字段默认值
+func (v *Validator) DefineDefaults() map[string]interface{} {
+	return nil
+}
+
+// 获取字段默认值
+func (v *Validator) GetDefaults() (defaults map[string]interface{}, err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	defaults = v.Defaults
+	return
+}
+
+// 设置字段默认值，键为字段（支持多级路径，如：items.*.qty），值为默认值，可为：
+// 固定值，如：1
+// func() interface{}，验证时调用获取默认值，如：func() interface{} { return time.Now() }
+// validate.SceneDefaults，各验证场景的默认值，如：validate.SceneDefaults{"create": 1, "*": 0}
+// 验证前数据未传入（SetDatas未传入、结构体属性初始化的零值）或为nil时设置默认值，设置后的值同步到对应的json标签属性上（不论字段是否在当前验证场景中）
+func (v *Validator) SetDefaults(defaults map[string]interface{}) (err error) {
+	err = v.GetError()
+	if err != nil {
+		return
+	}
+	v.Defaults = defaults
 	return
 }
 
@@ -420,6 +634,7 @@ func (v *Validator) GetDatas() (datas map[string]interface{}, err error) {
 }
 
 // 设置参与验证的数据（值会同步到对应的json标签属性上）
+// 值的类型与属性类型不匹配时返回系统错误，开启类型转换（Coerce）时转换为属性类型
 func (v *Validator) SetDatas(datas map[string]interface{}) (err error) {
 	err = v.GetError()
 	if err != nil {
@@ -428,7 +643,12 @@ func (v *Validator) SetDatas(datas map[string]interface{}) (err error) {
 	if datas == nil {
 		return
 	}
+	// 传入的数据均视为已传入的数据（包括再次传入的当前数据，如GetDatas后修改再传入），不再设置默认值
+	for key := range datas {
+		delete(v.zeroKeys, key)
+	}
 	v.Datas = datas
+	v.coerceErrs = nil
 
 	// 判断属性json标签，并赋值
 	// 获取结构体的类型
@@ -455,22 +675,62 @@ func (v *Validator) SetDatas(datas map[string]interface{}) (err error) {
 		if structField.CanSet() {
 			// 将传入的值转换为反射值
 			val := reflect.ValueOf(jsonTagValue)
-			// 检查值的类型是否匹配
-			if !val.Type().AssignableTo(structField.Type()) {
-				err = v.SetSystemError(fmt.Errorf("属性%s类型%v与传入值类型%v不匹配", field.Name, field.Type, val.Type()))
-				return
+			// 传入nil时设置为零值
+			if !val.IsValid() {
+				structField.Set(reflect.Zero(structField.Type()))
+				continue
 			}
 			// 检查值的类型是否与字段类型匹配
 			if val.Type().AssignableTo(structField.Type()) {
 				// 设置字段的值
 				structField.Set(val)
+				continue
+			}
+			if !v.Coerce {
+				err = v.SetSystemError(fmt.Errorf("属性%s类型%v与传入值类型%v不匹配", field.Name, field.Type, val.Type()))
+				return
+			}
+			// 转换为字段类型，转换失败时设置为零值，验证时返回该字段的类型错误
+			coerced, coerceErr := coerceValue(jsonTagValue, structField.Type())
+			if coerceErr != nil {
+				structField.Set(reflect.Zero(structField.Type()))
+				if v.coerceErrs == nil {
+					v.coerceErrs = map[string]error{}
+				}
+				v.coerceErrs[jsonTag] = coerceErr
+				continue
 			}
+			structField.Set(coerced)
 		}
 	}
 
 	return
 }
 
+// 根据完整路径设置验证数据（不同步到属性上），值不能设置到对应json标签的属性时不设置并返回false
+// 值的类型不能赋值给属性类型（开启类型转换时为不能转换为属性类型）时不能设置，下级数据同setValueByPath
+func (v *Validator) setDataValue(datas map[string]interface{}, path string, value interface{}) bool {
+	if value != nil {
+		for _, field := range v.plan.fields {
+			if field.Key != path {
+				continue
+			}
+			fieldType := v.validatorInstanceElem.Type().Field(field.Index).Type
+			if reflect.TypeOf(value).AssignableTo(fieldType) {
+				break
+			}
+			if !v.Coerce {
+				return false
+			}
+			if _, err := coerceValue(value, fieldType); err != nil {
+				return false
+			}
+			break
+		}
+	}
+	return setValueByPath(datas, path, value)
+}
+
 // 设置数据（值会同步到对应的json标签属性上）
 func (v *Validator) SetData(key string, value interface{}) (err error) {
 	err = v.GetError()
@@ -485,6 +745,7 @@ func (v *Validator) SetData(key string, value interface{}) (err error) {
 		return
 	}
 	datas[key] = value
+	delete(v.zeroKeys, key)
 	err = v.SetDatas(datas)
 	if err != nil {
 		return
@@ -495,7 +756,8 @@ func (v *Validator) SetData(key string, value interface{}) (err error) {
 
 // 初始化属性
 // scene 当前验证场景
-func (v *Validator) initAttr(scene string) (err error) {
+// opts 验证选项
+func (v *Validator) initAttr(scene string, opts []CheckOption) (err error) {
 	// 获取系统错误（系统错误直接返回）
 	sysErr := v.GetSystemError()
 	if sysErr != nil {
@@ -510,6 +772,7 @@ func (v *Validator) initAttr(scene string) (err error) {
 	if err != nil {
 		return
 	}
+	var sceneKeys []string
 	if scene != "" {
 		var scenes map[string][]string
 		scenes, err = v.GetScenes()
@@ -517,30 +780,83 @@ func (v *Validator) initAttr(scene string) (err error) {
 			return
 		}
 		if scenes != nil {
-			if sceneDatas, ok := scenes[scene]; ok {
-				for _, dataKey := range sceneDatas {
-					dataRules, ok := rules[dataKey]
-					if !ok {
-						err = v.SetSystemError(fmt.Sprintf("验证场景%s数据%s未定义验证规则！", scene, dataKey))
-						return
+			sceneKeys = scenes[scene]
+			for _, dataKey := range sceneKeys {
+				dataRules, ok := rules[dataKey]
+				if !ok {
+					// 子验证器属性可不定义验证规则
+					if _, isChild := v.childFields[dataKey]; isChild {
+						continue
 					}
-					checkRules[dataKey] = dataRules
+					err = v.SetSystemError(fmt.Sprintf("验证场景%s数据%s未定义验证规则！", scene, dataKey))
+					return
 				}
+				checkRules[dataKey] = dataRules
 			}
 		}
 	}
-	if len(checkRules) == 0 {
+	if len(sceneKeys) == 0 {
 		checkRules = rules
 	}
+	// 当前验证字段（包含子验证器属性）
+	checkKeys := make(map[string]interface{}, len(checkRules))
+	for dataKey, dataRules := range checkRules {
+		checkKeys[dataKey] = dataRules
+	}
+	for dataKey := range v.childFields {
+		if len(sceneKeys) == 0 || inArray(dataKey, sceneKeys) {
+			checkKeys[dataKey] = checkRules[dataKey]
+		}
+	}
+	// 当前验证字段顺序
+	order, err := v.GetOrder()
+	if err != nil {
+		return
+	}
 	v.Scene = scene
 	v.CheckRules = checkRules
+	v.CheckKeys = sortKeys(checkKeys, sceneKeys, v.fieldKeys, order)
+	// 当前是否批量验证
+	checkOpts := newCheckOptions(opts)
+	v.batch = v.Batch
+	if checkOpts.batch != nil {
+		v.batch = *checkOpts.batch
+	}
+	// 当前提示信息语言
+	v.locale = v.Locale
+	if checkOpts.locale != "" {
+		v.locale = checkOpts.locale
+	}
+	// 当前验证规则查找范围：本次验证的规则、验证器的局部规则、默认注册表
+	if checkOpts.err != nil {
+		err = v.SetSystemError(checkOpts.err)
+		return
+	}
+	// 类型转换在SetDatas时执行，验证时设置不会生效
+	if checkOpts.coerce != nil && !checkOpts.coerced {
+		err = v.SetSystemError("WithCoerce仅可用于Schema.Validate、Bind，验证器实例请在SetDatas前调用SetCoerce")
+		return
+	}
+	v.callRegistries = checkOpts.registries
+	v.rules = newRuleScope(append(append([]*Registry{}, checkOpts.registries...), v.registry)...)
 	return nil
 }
 
 // 验证指定环境数据
-func (v *Validator) CheckScene(scene string) (err error) {
+func (v *Validator) CheckScene(scene string, opts ...CheckOption) (err error) {
+	err = v.CheckSceneContext(context.Background(), scene, opts...)
+	return
+}
+
+// 验证指定环境数据，上下文取消、超时时中止验证并返回上下文错误（可通过errors.Is(err, context.Canceled)判断）
+// 上下文传递给验证规则的CtxFun、第1个参数为context.Context的验证器方法规则及闭包规则
+func (v *Validator) CheckSceneContext(ctx context.Context, scene string, opts ...CheckOption) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	v.ctx = ctx
 	// 初始化属性
-	err = v.initAttr(scene)
+	err = v.initAttr(scene, opts)
 	if err != nil {
 		return
 	}
@@ -550,8 +866,14 @@ func (v *Validator) CheckScene(scene string) (err error) {
 }
 
 // 验证
-func (v *Validator) Check() (err error) {
-	err = v.CheckScene("")
+func (v *Validator) Check(opts ...CheckOption) (err error) {
+	err = v.CheckScene("", opts...)
+	return
+}
+
+// 验证，上下文取消、超时时中止验证
+func (v *Validator) CheckContext(ctx context.Context, opts ...CheckOption) (err error) {
+	err = v.CheckSceneContext(ctx, "", opts...)
 	return
 }
 
@@ -571,64 +893,35 @@ func (v *Validator) callValidatorInstanceRuleMethod(methodName string, dataValue
 	if err != nil {
 		return
 	}
-	// 获取指定名称的方法
-	// 反射值为指针类型，指针可以调用值接收者方法和指针接收者方法
-	method := v.validatorInstancePtr.MethodByName(methodName)
-	// 判断方法是否有效
-	if !method.IsValid() || !method.CanInterface() {
-		err = v.SetSystemError(fmt.Sprintf("方法%s不可调用", methodName))
-		return
-	}
-
-	// 检查参数数量(有一个是接收者)
-	methodParamNum := method.Type().NumIn()
-	if methodParamNum != 4 {
-		err = v.SetSystemError(fmt.Sprintf("方法%s需定义4个参数，但实际有%d个参数", methodName, methodParamNum))
-		return
-	}
-	// 检查参数类型
-	no1MethodParamType := method.Type().In(0)
-	if no1MethodParamType.Kind() != reflect.Interface || no1MethodParamType.NumMethod() != 0 {
-		err = v.SetSystemError(fmt.Sprintf("方法%s的第1个参数类型不正确，需为interface{}", methodName))
-		return
-	}
-	no2MethodParamType := method.Type().In(1)
-	if no2MethodParamType.Kind() != reflect.String {
-		err = v.SetSystemError(fmt.Sprintf("方法%s的第2个参数类型不正确，需为string", methodName))
+	// 方法定义按类型检查一次
+	methodPlan := v.plan.method(v.validatorInstancePtr.Type(), methodName)
+	if methodPlan.err != nil {
+		err = v.SetSystemError(methodPlan.err.Error())
 		return
 	}
-	no3MethodParamType := method.Type().In(2)
-	if no3MethodParamType.Kind() != reflect.Map || no3MethodParamType.Key().Kind() != reflect.String || no3MethodParamType.Elem().Kind() != reflect.Interface {
-		err = v.SetSystemError(fmt.Sprintf("方法%s的第3个参数类型不正确，需为map[string]interface{}", methodName))
-		return
-	}
-	no4MethodParamType := method.Type().In(3)
-	if no4MethodParamType.Kind() != reflect.String {
-		err = v.SetSystemError(fmt.Sprintf("方法%s的第4个参数类型不正确，需为string", methodName))
-		return
-	}
-
-	// 检查返回值数量
-	methodRetuenNum := method.Type().NumOut()
-	if methodRetuenNum != 1 {
-		err = v.SetSystemError(fmt.Sprintf("方法%s只需返回1个错误结果，但实际有%d个变量返回", methodName, methodRetuenNum))
-		return
-	}
-
-	// 检查返回值类型
-	no1MethodReturnType := method.Type().Out(0)
-	if no1MethodReturnType != reflect.TypeOf((*error)(nil)).Elem() {
-		err = v.SetSystemError(fmt.Sprintf("方法%s的返回值类型不正确，需为error", methodName))
+	method := v.validatorInstancePtr.Method(methodPlan.index)
+	// 方法签名与规则函数类型一致时直接调用
+	if methodPlan.direct {
+		if methodPlan.ctx {
+			err = method.Interface().(methodRuleCtxFunc)(v.ctx, dataValue, ruleParam, datas, dataTitle)
+		} else {
+			err = method.Interface().(methodRuleFunc)(dataValue, ruleParam, datas, dataTitle)
+		}
 		return
 	}
 
 	// 准备反射调用所需的参数
-	paramValues := []reflect.Value{
-		reflect.ValueOf(dataValue),
-		reflect.ValueOf(ruleParam),
-		reflect.ValueOf(datas),
-		reflect.ValueOf(dataTitle),
-	}
+	paramValues := make([]reflect.Value, 0, 5)
+	if methodPlan.ctx {
+		paramValues = append(paramValues, reflect.ValueOf(&v.ctx).Elem())
+	}
+	offset := len(paramValues)
+	paramValues = append(paramValues,
+		reflect.ValueOf(&dataValue).Elem(),
+		reflect.ValueOf(ruleParam).Convert(method.Type().In(offset+1)),
+		reflect.ValueOf(datas).Convert(method.Type().In(offset+2)),
+		reflect.ValueOf(dataTitle).Convert(method.Type().In(offset+3)),
+	)
 
 	// 调用方法并获取返回值
 	results := method.Call(paramValues)
@@ -658,68 +951,98 @@ func (v *Validator) handleCheck() (err error) {
 	if err != nil {
 		return
 	}
-	for dataKey, dataRules := range checkRules {
-		if dataRules == nil {
+	// 验证前设置默认值
+	err = v.defaultDatas(datas)
+	if err != nil {
+		return
+	}
+	// 验证前过滤数据
+	filterErrs, err := v.filterDatas(datas, checkRules, messages, titles)
+	if err != nil {
+		return
+	}
+	errs := &Errors{}
+	for _, dataKey := range v.CheckKeys {
+		// 数据转换为属性类型失败时返回类型错误，不再验证规则
+		if _, ok := v.coerceErrs[dataKey]; ok {
+			err = v.coerceError(dataKey, datas, messages, titles)
+			if !v.batch {
+				return
+			}
+			errs.Add(dataKey, err)
+			err = nil
 			continue
 		}
-		dataValue, dataExists := datas[dataKey]
-		dataTitle, dataTitleExists := titles[dataKey]
-		if !dataTitleExists || dataTitle == "" {
-			dataTitle = dataKey
-		}
-		// 定义的规则字符串
-		if dataRuleStr, isStr := dataRules.(string); isStr {
-			if dataRuleStr == "" {
-				continue
+		dataFailed := false
+		// 多级路径可能匹配多个数据，如：items.*.sku
+		for _, data := range getPathValues(datas, dataKey) {
+			// 上下文取消、超时时中止验证
+			if err = v.ctx.Err(); err != nil {
+				return
+			}
+			// 过滤失败的数据返回过滤错误
+			if filterErr, ok := filterErrs[data.Path]; ok {
+				err = filterErr
+			} else {
+				err = v.checkData(dataKey, data, checkRules[dataKey], datas, messages, titles)
 			}
-			dataRuleSlice := strings.Split(dataRuleStr, "|")
-			// 判断数据是否为空
-			if dataRuleSlice[0] != "required" && (!dataExists || isEmpty(dataValue)) {
+			if err == nil {
 				continue
 			}
-			for _, dataRule := range dataRuleSlice {
-				if dataRule == "" {
-					continue
-				}
-				// 获取规则、规则参数
-				var ruleName, ruleParam string
-				colonIndex := strings.Index(dataRule, ":")
-				if colonIndex == -1 {
-					ruleName = dataRule
-					ruleParam = ""
-				} else {
-					ruleName = dataRule[:colonIndex]
-					ruleParam = dataRule[colonIndex+1:]
-				}
-				// 判断是否为注册的规则
-				if rule, ok := Rules[ruleName]; ok {
-					err = rule.Check(dataValue, ruleParam, datas, dataTitle)
-					if err != nil {
-						defineMessage := messages[dataKey+"."+ruleName]
-						if defineMessage != "" {
-							err = v.SetError(defineMessage)
-						}
-						return
-					}
-					continue
-				}
-				// 判断是否为结构体内可调用方法
-				err = v.callValidatorInstanceRuleMethod(ruleName, dataValue, ruleParam, datas, dataTitle)
-				if err != nil {
-					return
-				}
+			// 系统错误直接返回
+			if sysErr := v.GetSystemError(); sysErr != nil {
+				err = sysErr
+				return
 			}
-			continue
-		}
-		// 定义的规则为闭包验证方法
-		if dataRuleFun, isFun := dataRules.(func(value interface{}, datas map[string]interface{}, title string) error); isFun {
-			err = dataRuleFun(dataValue, datas, dataTitle)
-			if err != nil {
+			if isContextError(err) {
 				return
 			}
+			if !v.batch {
+				return
+			}
+			errs.Add(data.Path, err)
+			dataFailed = true
+			err = nil
+		}
+		if dataFailed {
 			continue
 		}
-		err = v.SetSystemError(fmt.Sprintf("参数%s验证规则定义需为string或func(value interface{}, datas map[string]interface{}, title string) error类型", dataKey))
+		// 验证子验证器
+		err = v.checkChild(dataKey, datas)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrSystem) {
+			err = v.SetSystemError(err)
+			return
+		}
+		if isContextError(err) {
+			return
+		}
+		if !v.batch {
+			return
+		}
+		errs.Merge(err)
+		err = nil
+	}
+	// 非当前验证字段（未定义验证规则、不在验证场景中）转换失败时同样返回类型错误，避免属性被静默设置为零值
+	otherKeys := []string{}
+	for dataKey := range v.coerceErrs {
+		if !inArray(dataKey, v.CheckKeys) {
+			otherKeys = append(otherKeys, dataKey)
+		}
+	}
+	sort.Strings(otherKeys)
+	for _, dataKey := range otherKeys {
+		err = v.coerceError(dataKey, datas, messages, titles)
+		if !v.batch {
+			return
+		}
+		errs.Add(dataKey, err)
+		err = nil
+	}
+	if errs.Len() > 0 {
+		err = errs
 		return
 	}
 	// 验证后处理数据
@@ -739,6 +1062,109 @@ func (v *Validator) handleCheck() (err error) {
 	return
 }
 
+// 数据转换为属性类型失败的类型错误
+func (v *Validator) coerceError(dataKey string, datas map[string]interface{}, messages map[string]string, titles map[string]string) error {
+	message := fieldMessage(messages, "type", dataKey, dataKey)
+	if message == "" {
+		message = translate(v.locale, "type")
+	}
+	return newFieldError(dataKey, fieldTitle(titles, dataKey, dataKey, v.locale), "type", "", datas[dataKey], v.coerceErrs[dataKey], message, v.locale)
+}
+
+// 验证单个字段
+// dataKey 定义验证规则的字段，如：items.*.sku
+// data 待验证的数据，其路径为数据完整路径，如：items.0.sku
+func (v *Validator) checkData(dataKey string, data pathValue, dataRules interface{}, datas map[string]interface{}, messages map[string]string, titles map[string]string) (err error) {
+	if dataRules == nil {
+		return
+	}
+	dataPath, dataValue, dataExists := data.Path, data.Value, data.Exists
+	dataTitle := fieldTitle(titles, dataPath, dataKey, v.locale)
+	// 定义的规则字符串
+	if dataRuleStr, isStr := dataRules.(string); isStr {
+		if dataRuleStr == "" {
+			return
+		}
+		ruleItems, parseErr := v.plan.ruleItems(dataRuleStr)
+		if parseErr != nil {
+			err = v.SetSystemError(parseErr)
+			return
+		}
+		// 判断数据是否为空
+		dataEmpty := !dataExists || isEmpty(dataValue)
+		for _, ruleItem := range ruleItems {
+			ruleName, ruleParam := ruleItem.Name, ruleItem.Param
+			// 过滤器已在验证前执行
+			if v.isFilter(ruleName) {
+				continue
+			}
+			// 数据为空时只验证必填类规则
+			if dataEmpty && !v.rules.isPresenceRule(ruleName) {
+				continue
+			}
+			// 上下文取消、超时时中止验证
+			if err = v.ctx.Err(); err != nil {
+				return
+			}
+			// 判断是否为注册的规则
+			if rule, ok := v.rules.Lookup(ruleName); ok {
+				err = rule.SetValidator(v.validatorInstance).CheckContext(v.ctx, dataValue, ruleParam, datas, dataTitle)
+				if err != nil {
+					// 验证规则配置错误
+					if errors.Is(err, ErrSystem) {
+						err = v.SetSystemError(err)
+						return
+					}
+					if isContextError(err) {
+						return
+					}
+					err = newFieldError(dataPath, dataTitle, ruleName, ruleParam, dataValue, err, ruleMessage(fieldMessage(messages, ruleName, dataPath, dataKey), rule, err, v.locale), v.locale)
+					return
+				}
+				continue
+			}
+			// 判断是否为结构体内可调用方法
+			err = v.callValidatorInstanceRuleMethod(ruleName, dataValue, ruleParam, datas, dataTitle)
+			if err != nil {
+				if errors.Is(err, ErrSystem) {
+					err = v.SetSystemError(err)
+					return
+				}
+				if isContextError(err) {
+					return
+				}
+				err = newFieldError(dataPath, dataTitle, ruleName, ruleParam, dataValue, err, fieldMessage(messages, ruleName, dataPath, dataKey), v.locale)
+				return
+			}
+		}
+		return
+	}
+	// 定义的规则为闭包验证方法
+	switch dataRuleFun := dataRules.(type) {
+	case func(value interface{}, datas map[string]interface{}, title string) error:
+		err = dataRuleFun(dataValue, datas, dataTitle)
+	case func(ctx context.Context, value interface{}, datas map[string]interface{}, title string) error:
+		if err = v.ctx.Err(); err != nil {
+			return
+		}
+		err = dataRuleFun(v.ctx, dataValue, datas, dataTitle)
+	default:
+		err = v.SetSystemError(fmt.Sprintf("参数%s验证规则定义需为string或func(value interface{}, datas map[string]interface{}, title string) error、func(ctx context.Context, value interface{}, datas map[string]interface{}, title string) error类型", dataKey))
+		return
+	}
+	if err != nil {
+		if errors.Is(err, ErrSystem) {
+			err = v.SetSystemError(err)
+			return
+		}
+		if isContextError(err) {
+			return
+		}
+		err = newFieldError(dataPath, dataTitle, "func", "", dataValue, err, fieldMessage(messages, "func", dataPath, dataKey), v.locale)
+	}
+	return
+}
+
 // 验证后处理数据
 func (v *Validator) HandleDatas(datas map[string]interface{}, scene string) error {
 	return nil